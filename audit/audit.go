@@ -0,0 +1,55 @@
+// Package audit keeps a durable record of who called which method,
+// when, from where and with what outcome. Records are written as JSON
+// lines, usually to a RotatingFile, and can be filtered back with Scan.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Outcomes of an audited call.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Record is a single audited call.
+type Record struct {
+	Time   time.Time `json:"ts"`
+	Method string    `json:"method"`
+	// ClaimedCaller is the caller as reported by the client, which is only
+	// authenticated in the authenticate mode of stringsvc.Tenancy.
+	ClaimedCaller string `json:"claimed_caller,omitempty"`
+	Tenant        string `json:"tenant,omitempty"`
+	// Remote is the address of the peer of the connection, and
+	// ClaimedForwardedFor the X-Forwarded-For header, which is reported
+	// by the client like ClaimedCaller.
+	Remote              string  `json:"remote,omitempty"`
+	ClaimedForwardedFor string  `json:"claimed_forwarded_for,omitempty"`
+	Transport           string  `json:"transport,omitempty"`
+	Outcome             string  `json:"outcome"`
+	Error               string  `json:"error,omitempty"`
+	InputSize           int     `json:"input_size"`
+	TookMs              float64 `json:"took_ms"`
+}
+
+// Logger writes records as JSON lines. It is safe for concurrent use.
+type Logger struct {
+	mtx sync.Mutex
+	enc *json.Encoder
+}
+
+// NewLogger returns a Logger writing to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{enc: json.NewEncoder(w)}
+}
+
+// Log writes a single record.
+func (l *Logger) Log(r Record) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.enc.Encode(r)
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Filter selects records. Zero fields match any record.
+type Filter struct {
+	Since  time.Time
+	Until  time.Time
+	Method string
+	// ClaimedCaller matches Record.ClaimedCaller.
+	ClaimedCaller string
+	Tenant        string
+	Outcome       string
+}
+
+// Match reports whether r is selected by the filter.
+func (f Filter) Match(r Record) bool {
+	switch {
+	case !f.Since.IsZero() && r.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !r.Time.Before(f.Until):
+		return false
+	case f.Method != "" && r.Method != f.Method:
+		return false
+	case f.ClaimedCaller != "" && r.ClaimedCaller != f.ClaimedCaller:
+		return false
+	case f.Tenant != "" && r.Tenant != f.Tenant:
+		return false
+	case f.Outcome != "" && r.Outcome != f.Outcome:
+		return false
+	}
+	return true
+}
+
+// Scan reads JSON lines records from r and calls fn for each one
+// matching the filter, stopping at the first error fn returns.
+func Scan(r io.Reader, f Filter, fn func(Record) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; s.Scan(); line++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(s.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		if !f.Match(rec) {
+			continue
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
@@ -0,0 +1,205 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is appended to the name of rotated files, so that
+// they sort in the order they were written.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.WriteCloser that appends to the file at Path and
+// moves it aside once it grows beyond MaxSize bytes or gets older than
+// MaxAge. Zero limits disable the respective rotation. At most MaxBackups
+// rotated files are kept, all of them when it is zero.
+type RotatingFile struct {
+	Path       string
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+
+	mtx     sync.Mutex
+	file    *os.File
+	size    int64
+	created time.Time
+	err     error
+}
+
+// Write implements io.Writer. The file is rotated before a write that
+// would exceed the limits, so a single write never spans two files.
+func (f *RotatingFile) Write(p []byte) (n int, err error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	defer func() { f.err = err }()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Check returns the error of the last write, if it failed.
+// It implements health.Checker.
+func (f *RotatingFile) Check(context.Context) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.err
+}
+
+// Close closes the current file.
+func (f *RotatingFile) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.created = time.Now()
+	if f.size > 0 {
+		f.created = f.started(info)
+	}
+	return nil
+}
+
+// started returns when the current file was started after a restart: the
+// time of its first record or else of the newest backup, rotated when it
+// was created. Its modification time, that of the last write, is only a
+// last resort.
+func (f *RotatingFile) started(info os.FileInfo) time.Time {
+	if file, err := os.Open(f.Path); err == nil {
+		var first Record
+		err := json.NewDecoder(file).Decode(&first)
+		file.Close()
+		if err == nil && !first.Time.IsZero() {
+			return first.Time
+		}
+	}
+	if backups, err := Backups(f.Path); err == nil && len(backups) > 0 {
+		if t, ok := backupTime(f.Path, backups[len(backups)-1]); ok {
+			return t
+		}
+	}
+	return info.ModTime()
+}
+
+func (f *RotatingFile) due(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.MaxSize > 0 && f.size+n > f.MaxSize {
+		return true
+	}
+	return f.MaxAge > 0 && time.Since(f.created) >= f.MaxAge
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	stamp := time.Now().UTC().Format(backupTimeFormat)
+	backup := fmt.Sprintf("%s.%s", f.Path, stamp)
+	for i := 1; exists(backup); i++ {
+		backup = fmt.Sprintf("%s.%s-%d", f.Path, stamp, i)
+	}
+	if err := os.Rename(f.Path, backup); err != nil {
+		return err
+	}
+	if err := f.prune(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+func (f *RotatingFile) prune() error {
+	if f.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := Backups(f.Path)
+	if err != nil {
+		return err
+	}
+	for len(backups) > f.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// Backups returns the rotated files of the file at path, oldest first.
+// Other files named after it, such as path.bak, are left out.
+func Backups(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, m := range matches {
+		if _, ok := backupTime(path, m); ok {
+			backups = append(backups, m)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// backupTime returns the time the file at path was rotated to backup,
+// named by rotate with the time and, if taken, a counter.
+func backupTime(path, backup string) (time.Time, bool) {
+	if !strings.HasPrefix(backup, path+".") {
+		return time.Time{}, false
+	}
+	stamp := backup[len(path)+1:]
+	if i := strings.LastIndexByte(stamp, '-'); i >= 0 {
+		if n := stamp[i+1:]; n == "" || strings.Trim(n, "0123456789") != "" {
+			return time.Time{}, false
+		}
+		stamp = stamp[:i]
+	}
+	t, err := time.Parse(backupTimeFormat, stamp)
+	return t, err == nil
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	for _, name := range []string{
+		"audit.log",
+		"audit.log.20240102T150405.000-1",
+		"audit.log.20240102T150405.000",
+		"audit.log.20230102T150405.123",
+		"audit.log.bak",
+		"audit.log.lock",
+		"audit.log.20240102T150405.000-",
+		"audit.log.20240102T150405.000-x",
+		"audit.log.20240102T150405",
+		"audit.logs.20240102T150405.000",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Backups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		path + ".20230102T150405.123",
+		path + ".20240102T150405.000",
+		path + ".20240102T150405.000-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRotateAfterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	f := &RotatingFile{Path: path, MaxAge: time.Hour}
+	if err := NewLogger(f).Log(Record{Time: time.Now().Add(-2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// The file was written to recently but started over MaxAge ago.
+	f = &RotatingFile{Path: path, MaxAge: time.Hour}
+	defer f.Close()
+	if err := NewLogger(f).Log(Record{Time: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if backups, err := Backups(path); err != nil || len(backups) != 1 {
+		t.Errorf("got backups %q, %v, want one", backups, err)
+	}
+}
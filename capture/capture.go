@@ -0,0 +1,174 @@
+// Package capture records decoded requests and responses of the string
+// service as JSON lines, so that real traffic can be replayed against
+// another instance and the responses compared.
+package capture
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the parts of captured strings matched by redaction patterns.
+const Redacted = "[REDACTED]"
+
+// Entry is a single captured call.
+type Entry struct {
+	Time     time.Time       `json:"ts"`
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Redacted bool            `json:"redacted,omitempty"`
+}
+
+// Options of a Recorder.
+type Options struct {
+	// SampleRate is the ratio of calls recorded, from 0 to 1.
+	SampleRate float64
+	// Redact patterns are replaced in every string of requests and responses.
+	Redact []*regexp.Regexp
+}
+
+// Recorder writes entries as JSON lines. It is safe for concurrent use.
+type Recorder struct {
+	mtx  sync.Mutex
+	opts Options
+	enc  *json.Encoder
+	rand *rand.Rand
+	err  error
+}
+
+// NewRecorder returns a Recorder writing to w.
+func NewRecorder(w io.Writer, opts Options) *Recorder {
+	return &Recorder{
+		opts: opts,
+		enc:  json.NewEncoder(w),
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetOptions replaces the options of the Recorder.
+func (r *Recorder) SetOptions(opts Options) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.opts = opts
+}
+
+func (r *Recorder) options() Options {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.opts
+}
+
+// Sample reports whether the next call should be recorded.
+func (r *Recorder) Sample() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.opts.SampleRate >= 1 || r.rand.Float64() < r.opts.SampleRate
+}
+
+// Record writes the request and response of a call of method
+// with redaction patterns applied.
+func (r *Recorder) Record(method string, request, response interface{}, err error) error {
+	e := Entry{Time: time.Now().UTC(), Method: method}
+	redact := r.options().Redact
+	req, redacted, encErr := encode(request, redact)
+	if encErr != nil {
+		return encErr
+	}
+	e.Request, e.Redacted = req, redacted
+	if err != nil {
+		e.Error = err.Error()
+	} else if response != nil {
+		resp, redacted, encErr := encode(response, redact)
+		if encErr != nil {
+			return encErr
+		}
+		e.Response, e.Redacted = resp, e.Redacted || redacted
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.err = r.enc.Encode(e)
+	return r.err
+}
+
+// Check returns the error of the last write, if it failed.
+// It implements health.Checker.
+func (r *Recorder) Check(context.Context) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.err
+}
+
+func encode(v interface{}, patterns []*regexp.Regexp) (json.RawMessage, bool, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(patterns) == 0 {
+		return b, false, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, false, err
+	}
+	doc, redacted := redact(doc, patterns)
+	if !redacted {
+		return b, false, nil
+	}
+	b, err = json.Marshal(doc)
+	return b, true, err
+}
+
+// redact replaces matches of the patterns in all strings of a decoded JSON document.
+func redact(v interface{}, patterns []*regexp.Regexp) (interface{}, bool) {
+	var redacted bool
+	switch v := v.(type) {
+	case string:
+		for _, re := range patterns {
+			if re.MatchString(v) {
+				v = re.ReplaceAllLiteralString(v, Redacted)
+				redacted = true
+			}
+		}
+		return v, redacted
+	case []interface{}:
+		for i := range v {
+			var ok bool
+			v[i], ok = redact(v[i], patterns)
+			redacted = redacted || ok
+		}
+	case map[string]interface{}:
+		for k := range v {
+			var ok bool
+			v[k], ok = redact(v[k], patterns)
+			redacted = redacted || ok
+		}
+	}
+	return v, redacted
+}
+
+// Read decodes JSON lines entries from r and calls fn for each one,
+// stopping at the first error fn returns.
+func Read(r io.Reader, fn func(Entry) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; s.Scan(); line++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
@@ -0,0 +1,102 @@
+// Package client spreads StringService calls over the instances of the
+// service found in a service discovery system.
+package client
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/stringsvc"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/sd"
+	"github.com/go-kit/kit/sd/lb"
+)
+
+// Factory returns the Endpoints of the service at instance, along with
+// a Closer releasing their resources once the instance goes away.
+type Factory func(instance string) (stringsvc.Endpoints, io.Closer, error)
+
+// NewBalanced returns StringService Endpoints invoking the instances of
+// instancer round robin. Calls failing with an error are retried on the
+// next instance, up to retries times within timeout. The factory is called
+// once per instance, its Endpoints being shared by all methods.
+func NewBalanced(instancer sd.Instancer, factory Factory, retries int, timeout time.Duration, logger log.Logger) stringsvc.Endpoints {
+	shared := &sharedInstances{factory: factory, instances: map[string]*sharedInstance{}}
+	balanced := func(method string) endpoint.Endpoint {
+		endpointer := sd.NewEndpointer(instancer, shared.factoryFor(method), log.With(logger, "method", method))
+		return lb.Retry(retries, timeout, lb.NewRoundRobin(endpointer))
+	}
+	return stringsvc.Endpoints{
+		TitleCaseEndpoint:        balanced(stringsvc.MethodTitleCase),
+		RemoveWhitespaceEndpoint: balanced(stringsvc.MethodRemoveWhitespace),
+		CountEndpoint:            balanced(stringsvc.MethodCount),
+		ConvertCaseEndpoint:      balanced(stringsvc.MethodConvertCase),
+		StatsEndpoint:            balanced(stringsvc.MethodStats),
+		NormalizeEndpoint:        balanced(stringsvc.MethodNormalize),
+		SlugifyEndpoint:          balanced(stringsvc.MethodSlugify),
+		SimilarityEndpoint:       balanced(stringsvc.MethodSimilarity),
+		MatchEndpoint:            balanced(stringsvc.MethodMatch),
+		FindAllEndpoint:          balanced(stringsvc.MethodFindAll),
+		ReplaceAllEndpoint:       balanced(stringsvc.MethodReplaceAll),
+		WrapEndpoint:             balanced(stringsvc.MethodWrap),
+		TruncateEndpoint:         balanced(stringsvc.MethodTruncate),
+		VersionEndpoint:          balanced(stringsvc.MethodVersion),
+	}
+}
+
+// sharedInstances hands out the Endpoints of every instance to the
+// endpointers of all methods, closing them once none of the endpointers
+// uses the instance any longer.
+type sharedInstances struct {
+	factory Factory
+
+	mtx       sync.Mutex
+	instances map[string]*sharedInstance
+}
+
+type sharedInstance struct {
+	endpoints stringsvc.Endpoints
+	closer    io.Closer
+	refs      int
+}
+
+// factoryFor returns an sd.Factory of the endpoint of method.
+func (s *sharedInstances) factoryFor(method string) sd.Factory {
+	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		inst, ok := s.instances[instance]
+		if !ok {
+			e, closer, err := s.factory(instance)
+			if err != nil {
+				return nil, nil, err
+			}
+			inst = &sharedInstance{endpoints: e, closer: closer}
+			s.instances[instance] = inst
+		}
+		inst.refs++
+		return inst.endpoints.Endpoint(method), closerFunc(func() error { return s.release(instance, inst) }), nil
+	}
+}
+
+// release drops a reference to inst, closing it with the last one.
+func (s *sharedInstances) release(instance string, inst *sharedInstance) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if inst.refs--; inst.refs > 0 {
+		return nil
+	}
+	if s.instances[instance] == inst {
+		delete(s.instances, instance)
+	}
+	if inst.closer == nil {
+		return nil
+	}
+	return inst.closer.Close()
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"io"
+	"testing"
+
+	"github.com/afrometal/go-kit-svc/stringsvc"
+)
+
+type countingCloser struct{ closed int }
+
+func (c *countingCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestSharedInstances(t *testing.T) {
+	var (
+		calls   int
+		closers []*countingCloser
+	)
+	shared := &sharedInstances{
+		factory: func(instance string) (stringsvc.Endpoints, io.Closer, error) {
+			calls++
+			c := &countingCloser{}
+			closers = append(closers, c)
+			return stringsvc.NewEndpoints(stringsvc.New()), c, nil
+		},
+		instances: map[string]*sharedInstance{},
+	}
+
+	var released []io.Closer
+	for _, method := range append([]string{stringsvc.MethodVersion}, stringsvc.Methods...) {
+		e, closer, err := shared.factoryFor(method)("a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e == nil {
+			t.Fatalf("no endpoint of %s", method)
+		}
+		released = append(released, closer)
+	}
+	if calls != 1 {
+		t.Fatalf("factory called %d times, want 1", calls)
+	}
+
+	for i, closer := range released {
+		if closers[0].closed != 0 {
+			t.Fatalf("instance closed after %d of %d endpoints were", i, len(released))
+		}
+		closer.Close()
+	}
+	if closers[0].closed != 1 {
+		t.Fatalf("instance closed %d times, want 1", closers[0].closed)
+	}
+
+	// An instance coming back gets new Endpoints.
+	if _, _, err := shared.factoryFor(stringsvc.MethodCount)("a"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("factory called %d times after the instance came back, want 2", calls)
+	}
+}
@@ -2,20 +2,32 @@
 package grpc
 
 import (
+	"context"
+	"net"
+
+	"github.com/afrometal/go-kit-svc/listen"
 	"github.com/afrometal/go-kit-svc/stringsvc"
 	"github.com/afrometal/go-kit-svc/stringsvc/proto"
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc"
 )
 
-// New returns StringService based on gRPC client connection.
-// Caller have to dial and close the connection.
-func New(conn *grpc.ClientConn) stringsvc.StringService {
+// New returns StringService Endpoints based on gRPC client connection.
+// Caller have to dial and close the connection, see Dial. Options apply
+// to the clients of all endpoints. Calls send the API version of the
+// client and fail with a stringsvc.VersionError if the server does not
+// serve it. Errors of patterns are returned as a stringsvc.PatternError.
+func New(conn *grpc.ClientConn, options ...grpctransport.ClientOption) stringsvc.Endpoints {
+	options = append([]grpctransport.ClientOption{
+		grpctransport.ClientBefore(stringsvc.SetGRPCVersion),
+		grpctransport.ClientAfter(stringsvc.GRPCServerVersion),
+	}, options...)
 	var titleCaseEndpoint = grpctransport.NewClient(
 		conn, "proto.String", "TitleCase",
 		stringsvc.EncodeGRPCTitleCaseRequest,
 		stringsvc.DecodeGRPCTitleCaseResponse,
 		proto.TitleCaseResponse{},
+		options...,
 	).Endpoint()
 
 	var removeWhitespaceEndpoint = grpctransport.NewClient(
@@ -23,6 +35,7 @@ func New(conn *grpc.ClientConn) stringsvc.StringService {
 		stringsvc.EncodeGRPCRemoveWhitespaceRequest,
 		stringsvc.DecodeGRPCRemoveWhitespaceResponse,
 		proto.RemoveWhitespaceResponse{},
+		options...,
 	).Endpoint()
 
 	var countEndpoint = grpctransport.NewClient(
@@ -30,11 +43,124 @@ func New(conn *grpc.ClientConn) stringsvc.StringService {
 		stringsvc.EncodeGRPCCountRequest,
 		stringsvc.DecodeGRPCCountResponse,
 		proto.CountResponse{},
+		options...,
+	).Endpoint()
+
+	var convertCaseEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "ConvertCase",
+		stringsvc.EncodeGRPCConvertCaseRequest,
+		stringsvc.DecodeGRPCConvertCaseResponse,
+		proto.ConvertCaseResponse{},
+		options...,
+	).Endpoint()
+
+	var statsEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Stats",
+		stringsvc.EncodeGRPCStatsRequest,
+		stringsvc.DecodeGRPCStatsResponse,
+		proto.StatsResponse{},
+		options...,
+	).Endpoint()
+
+	var normalizeEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Normalize",
+		stringsvc.EncodeGRPCNormalizeRequest,
+		stringsvc.DecodeGRPCNormalizeResponse,
+		proto.NormalizeResponse{},
+		options...,
+	).Endpoint()
+
+	var slugifyEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Slugify",
+		stringsvc.EncodeGRPCSlugifyRequest,
+		stringsvc.DecodeGRPCSlugifyResponse,
+		proto.SlugifyResponse{},
+		options...,
+	).Endpoint()
+
+	var similarityEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Similarity",
+		stringsvc.EncodeGRPCSimilarityRequest,
+		stringsvc.DecodeGRPCSimilarityResponse,
+		proto.SimilarityResponse{},
+		options...,
+	).Endpoint()
+
+	var matchEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Match",
+		stringsvc.EncodeGRPCMatchRequest,
+		stringsvc.DecodeGRPCMatchResponse,
+		proto.MatchResponse{},
+		options...,
+	).Endpoint()
+
+	var findAllEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "FindAll",
+		stringsvc.EncodeGRPCFindAllRequest,
+		stringsvc.DecodeGRPCFindAllResponse,
+		proto.FindAllResponse{},
+		options...,
+	).Endpoint()
+
+	var replaceAllEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "ReplaceAll",
+		stringsvc.EncodeGRPCReplaceAllRequest,
+		stringsvc.DecodeGRPCReplaceAllResponse,
+		proto.ReplaceAllResponse{},
+		options...,
+	).Endpoint()
+
+	var wrapEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Wrap",
+		stringsvc.EncodeGRPCWrapRequest,
+		stringsvc.DecodeGRPCWrapResponse,
+		proto.WrapResponse{},
+		options...,
+	).Endpoint()
+
+	var truncateEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Truncate",
+		stringsvc.EncodeGRPCTruncateRequest,
+		stringsvc.DecodeGRPCTruncateResponse,
+		proto.TruncateResponse{},
+		options...,
+	).Endpoint()
+
+	var versionEndpoint = grpctransport.NewClient(
+		conn, "proto.String", "Version",
+		stringsvc.EncodeGRPCVersionRequest,
+		stringsvc.DecodeGRPCVersionResponse,
+		proto.VersionResponse{},
+		options...,
 	).Endpoint()
 
 	return stringsvc.Endpoints{
-		TitleCaseEndpoint:        titleCaseEndpoint,
-		RemoveWhitespaceEndpoint: removeWhitespaceEndpoint,
-		CountEndpoint:            countEndpoint,
+		TitleCaseEndpoint:        stringsvc.CheckServerVersion(titleCaseEndpoint),
+		RemoveWhitespaceEndpoint: stringsvc.CheckServerVersion(removeWhitespaceEndpoint),
+		CountEndpoint:            stringsvc.CheckServerVersion(countEndpoint),
+		ConvertCaseEndpoint:      stringsvc.CheckServerVersion(convertCaseEndpoint),
+		StatsEndpoint:            stringsvc.CheckServerVersion(statsEndpoint),
+		NormalizeEndpoint:        stringsvc.CheckServerVersion(normalizeEndpoint),
+		SlugifyEndpoint:          stringsvc.CheckServerVersion(slugifyEndpoint),
+		SimilarityEndpoint:       stringsvc.CheckServerVersion(similarityEndpoint),
+		MatchEndpoint:            stringsvc.CheckServerVersion(stringsvc.DecodeGRPCError(matchEndpoint)),
+		FindAllEndpoint:          stringsvc.CheckServerVersion(stringsvc.DecodeGRPCError(findAllEndpoint)),
+		ReplaceAllEndpoint:       stringsvc.CheckServerVersion(stringsvc.DecodeGRPCError(replaceAllEndpoint)),
+		WrapEndpoint:             stringsvc.CheckServerVersion(wrapEndpoint),
+		TruncateEndpoint:         stringsvc.CheckServerVersion(truncateEndpoint),
+		VersionEndpoint:          versionEndpoint,
+	}
+}
+
+// Dial connects to the gRPC server at target, given in "host:port" form
+// or as "unix:///path" of a Unix socket.
+func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if path, ok := listen.UnixPath(target); ok {
+		var d net.Dialer
+		opts = append([]grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return d.DialContext(ctx, "unix", path)
+		})}, opts...)
+		target = "passthrough:///localhost"
 	}
+	return grpc.Dial(target, opts...)
 }
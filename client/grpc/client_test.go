@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/afrometal/go-kit-svc/stringsvc"
+	"github.com/afrometal/go-kit-svc/stringsvc/proto"
+	"github.com/go-kit/kit/log"
+	"google.golang.org/grpc"
+)
+
+func TestPatternError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	proto.RegisterStringServer(server, stringsvc.MakeGRPCServer(stringsvc.NewEndpoints(stringsvc.New()), log.NewNopLogger()))
+	go server.Serve(ln)
+	defer server.Stop()
+
+	conn, err := Dial(ln.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := New(conn)
+
+	for _, tc := range []struct {
+		pattern string
+		offset  int
+	}{
+		{"é[z-a]", 2},
+		{"(a", -1},
+	} {
+		_, err := client.Match(context.Background(), "x", tc.pattern)
+		perr, ok := err.(stringsvc.PatternError)
+		if !ok {
+			t.Errorf("%q: got %T %v, want a PatternError", tc.pattern, err, err)
+			continue
+		}
+		if perr.Pattern != tc.pattern || perr.Offset != tc.offset || perr.Reason == "" {
+			t.Errorf("%q: got %+v, want offset %d", tc.pattern, perr, tc.offset)
+		}
+	}
+}
@@ -2,16 +2,31 @@
 package http
 
 import (
+	"context"
+	"net"
+	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/afrometal/go-kit-svc/listen"
 	"github.com/afrometal/go-kit-svc/stringsvc"
 	httptransport "github.com/go-kit/kit/transport/http"
 )
 
-// New returns StringService based on HTTP server at remote instance.
-// Instance is expected to come in "host:port" form.
-func New(instance string) stringsvc.StringService {
+// New returns StringService Endpoints based on HTTP server at remote instance.
+// Instance is expected to come in "host:port" form, as an "https://" URL
+// to use TLS or as "unix:///path" of a Unix socket. Options apply to the
+// clients of all endpoints. Calls send the API version of the client and
+// fail with a stringsvc.VersionError if the server does not serve it.
+func New(instance string, options ...httptransport.ClientOption) stringsvc.Endpoints {
+	options = append([]httptransport.ClientOption{
+		httptransport.ClientBefore(stringsvc.SetHTTPVersion),
+		httptransport.ClientAfter(stringsvc.HTTPServerVersion),
+	}, options...)
+	if path, ok := listen.UnixPath(instance); ok {
+		instance = "http://unix"
+		options = append([]httptransport.ClientOption{httptransport.SetClient(unixClient(path))}, options...)
+	}
 	if !strings.HasPrefix(instance, "http") {
 		instance = "http://" + instance
 	}
@@ -24,6 +39,7 @@ func New(instance string) stringsvc.StringService {
 		copyURL(u, "/tc"),
 		stringsvc.EncodeHTTPRequest,
 		stringsvc.DecodeHTTPTitleCaseResponse,
+		options...,
 	).Endpoint()
 
 	var removeWhitespaceEndpoint = httptransport.NewClient(
@@ -31,6 +47,7 @@ func New(instance string) stringsvc.StringService {
 		copyURL(u, "/rw"),
 		stringsvc.EncodeHTTPRequest,
 		stringsvc.DecodeHTTPRemoveWhitespaceResponse,
+		options...,
 	).Endpoint()
 
 	var countEndpoint = httptransport.NewClient(
@@ -38,12 +55,124 @@ func New(instance string) stringsvc.StringService {
 		copyURL(u, "/c"),
 		stringsvc.EncodeHTTPRequest,
 		stringsvc.DecodeHTTPCountResponse,
+		options...,
+	).Endpoint()
+
+	var convertCaseEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/cc"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPConvertCaseResponse,
+		options...,
+	).Endpoint()
+
+	var statsEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/stats"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPStatsResponse,
+		options...,
+	).Endpoint()
+
+	var normalizeEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/norm"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPNormalizeResponse,
+		options...,
+	).Endpoint()
+
+	var slugifyEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/slug"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPSlugifyResponse,
+		options...,
+	).Endpoint()
+
+	var similarityEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/sim"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPSimilarityResponse,
+		options...,
+	).Endpoint()
+
+	var matchEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/match"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPMatchResponse,
+		options...,
+	).Endpoint()
+
+	var findAllEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/findall"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPFindAllResponse,
+		options...,
+	).Endpoint()
+
+	var replaceAllEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/replace"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPReplaceAllResponse,
+		options...,
+	).Endpoint()
+
+	var wrapEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/wrap"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPWrapResponse,
+		options...,
+	).Endpoint()
+
+	var truncateEndpoint = httptransport.NewClient(
+		"POST",
+		copyURL(u, "/trunc"),
+		stringsvc.EncodeHTTPRequest,
+		stringsvc.DecodeHTTPTruncateResponse,
+		options...,
+	).Endpoint()
+
+	var versionEndpoint = httptransport.NewClient(
+		"GET",
+		copyURL(u, "/version"),
+		stringsvc.EncodeHTTPVersionRequest,
+		stringsvc.DecodeHTTPVersionResponse,
+		options...,
 	).Endpoint()
 
 	return stringsvc.Endpoints{
-		TitleCaseEndpoint:        titleCaseEndpoint,
-		RemoveWhitespaceEndpoint: removeWhitespaceEndpoint,
-		CountEndpoint:            countEndpoint,
+		TitleCaseEndpoint:        stringsvc.CheckServerVersion(titleCaseEndpoint),
+		RemoveWhitespaceEndpoint: stringsvc.CheckServerVersion(removeWhitespaceEndpoint),
+		CountEndpoint:            stringsvc.CheckServerVersion(countEndpoint),
+		ConvertCaseEndpoint:      stringsvc.CheckServerVersion(convertCaseEndpoint),
+		StatsEndpoint:            stringsvc.CheckServerVersion(statsEndpoint),
+		NormalizeEndpoint:        stringsvc.CheckServerVersion(normalizeEndpoint),
+		SlugifyEndpoint:          stringsvc.CheckServerVersion(slugifyEndpoint),
+		SimilarityEndpoint:       stringsvc.CheckServerVersion(similarityEndpoint),
+		MatchEndpoint:            stringsvc.CheckServerVersion(matchEndpoint),
+		FindAllEndpoint:          stringsvc.CheckServerVersion(findAllEndpoint),
+		ReplaceAllEndpoint:       stringsvc.CheckServerVersion(replaceAllEndpoint),
+		WrapEndpoint:             stringsvc.CheckServerVersion(wrapEndpoint),
+		TruncateEndpoint:         stringsvc.CheckServerVersion(truncateEndpoint),
+		VersionEndpoint:          versionEndpoint,
+	}
+}
+
+// unixClient returns a HTTP client connecting to the socket at path.
+func unixClient(path string) *http.Client {
+	var d net.Dialer
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
 	}
 }
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/audit"
+)
+
+// runAudit prints the records of the audit files that match the filter
+// given in args, one JSON line per record.
+func runAudit(args []string) {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	var (
+		since   = fs.String("since", "", "only records at or after, RFC 3339 time or duration ago")
+		until   = fs.String("until", "", "only records before, RFC 3339 time or duration ago")
+		method  = fs.String("method", "", "only records of the method, e.g. title_case")
+		caller  = fs.String("claimed-caller", "", "only records of the caller claimed by the client")
+		tenant  = fs.String("tenant", "", "only records of the tenant")
+		outcome = fs.String("outcome", "", "only records with the outcome, success or error")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: audit [flags] file...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	f := audit.Filter{Method: *method, ClaimedCaller: *caller, Tenant: *tenant, Outcome: *outcome}
+	var err error
+	if f.Since, err = parseTime(*since); err != nil {
+		log.Fatalln("invalid -since:", err)
+	}
+	if f.Until, err = parseTime(*until); err != nil {
+		log.Fatalln("invalid -until:", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, name := range fs.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = audit.Scan(file, f, func(r audit.Record) error {
+			return enc.Encode(r)
+		})
+		file.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+// parseTime accepts either an RFC 3339 time or a duration before now.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -2,87 +2,449 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/afrometal/go-kit-svc/client"
 	grpcclient "github.com/afrometal/go-kit-svc/client/grpc"
 	httpclient "github.com/afrometal/go-kit-svc/client/http"
+	"github.com/afrometal/go-kit-svc/config"
+	"github.com/afrometal/go-kit-svc/discovery"
+	"github.com/afrometal/go-kit-svc/listen"
 	"github.com/afrometal/go-kit-svc/stringsvc"
+	kitlog "github.com/go-kit/kit/log"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	httptransport "github.com/go-kit/kit/transport/http"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// Config is the configuration of the client. It is read from the file
+// given with -config or STRINGSVC_CLIENT_CONFIG, STRINGSVC_CLIENT_*
+// environment variables and flags, see package config.
+type Config struct {
+	HTTPAddr string `yaml:"http_addr" usage:"HTTP address"`
+	GRPCAddr string `yaml:"grpc_addr" usage:"gRPC address"`
+	Tenant   string `yaml:"tenant" usage:"tenant the calls are made for"`
+
+	TLS struct {
+		Enabled bool   `yaml:"enabled" usage:"connect over TLS"`
+		CAFile  string `yaml:"ca_file" usage:"CA certificate file verifying the server, system roots are used if empty"`
+	} `yaml:"tls"`
+
+	Discovery struct {
+		Dir       string        `yaml:"dir" usage:"registry directory to discover instances in if no address is given"`
+		Transport string        `yaml:"transport" usage:"transport of discovered instances: http or grpc"`
+		Retries   int           `yaml:"retries" usage:"number of instances a failing call is tried on"`
+		Timeout   time.Duration `yaml:"timeout" usage:"duration all tries of a call are given"`
+	} `yaml:"discovery"`
+
+	TC struct {
+		Language string `yaml:"language" usage:"BCP 47 language whose capitalization rules tc applies, such as nl or tr"`
+		Style    string `yaml:"style" usage:"title case style of tc: plain, ap, chicago or apa"`
+	} `yaml:"tc"`
+
+	RW struct {
+		Mode  string `yaml:"mode" usage:"whitespace removed by rw: all, collapse, trim, keep_newlines or horizontal"`
+		Class string `yaml:"class" usage:"characters taken for whitespace by rw, as a regular expression character class"`
+	} `yaml:"rw"`
+
+	C struct {
+		Unit string `yaml:"unit" usage:"unit counted by c: bytes, runes, graphemes, words, lines, sentences or width"`
+	} `yaml:"c"`
+
+	Slug struct {
+		Separator string   `yaml:"separator" usage:"separator of the words of slug, - if empty"`
+		MaxLength int      `yaml:"max_length" usage:"length in runes of slug at most, unlimited if 0"`
+		StopWords []string `yaml:"stop_words" usage:"word left out of slug, may be repeated"`
+		Existing  []string `yaml:"existing" usage:"slug already taken, suffixed by slug to keep it unique, may be repeated"`
+	} `yaml:"slug"`
+
+	Wrap struct {
+		Align     string `yaml:"align" usage:"alignment of wrap: left, right, center or justify"`
+		Indent    int    `yaml:"indent" usage:"spaces before the first line of every paragraph of wrap"`
+		Hanging   int    `yaml:"hanging" usage:"spaces before the lines following the first of every paragraph of wrap"`
+		LongWords string `yaml:"long_words" usage:"words wider than a line of wrap: overflow or break"`
+	} `yaml:"wrap"`
+
+	Trunc struct {
+		Unit         string `yaml:"unit" usage:"unit of the limit of trunc: bytes, runes, graphemes or width"`
+		WordBoundary bool   `yaml:"word_boundary" usage:"cut trunc after the last word that fits"`
+		Ellipsis     string `yaml:"ellipsis" usage:"appended to text cut by trunc, such as …"`
+	} `yaml:"trunc"`
+}
+
+// Validate implements config.Validator.
+func (c *Config) Validate() error {
+	var errs config.Errors
+	errs.Check(c.Discovery.Transport == "http" || c.Discovery.Transport == "grpc",
+		"discovery.transport", "must be http or grpc, got %q", c.Discovery.Transport)
+	errs.Check(c.Discovery.Retries > 0, "discovery.retries", "must be positive, got %d", c.Discovery.Retries)
+	errs.Check(c.Discovery.Timeout > 0, "discovery.timeout", "must be positive, got %s", c.Discovery.Timeout)
+	return errs.Err()
+}
+
+// tlsConfig returns the TLS configuration of the client, nil if disabled.
+func (c Config) tlsConfig() (*tls.Config, error) {
+	if !c.TLS.Enabled && c.TLS.CAFile == "" {
+		return nil, nil
+	}
+	conf := &tls.Config{}
+	if c.TLS.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates found", c.TLS.CAFile)
+		}
+	}
+	return conf, nil
+}
+
 func main() {
-	var (
-		httpAddr = flag.String("http-addr", "",
-			"HTTP address")
-		grpcAddr = flag.String("grpc-addr", "",
-			"gRPC address")
-	)
-	flag.Parse()
+	var cfg Config
+	cfg.Discovery.Transport = "grpc"
+	cfg.Discovery.Retries = 3
+	cfg.Discovery.Timeout = 5 * time.Second
+	loader := config.Loader{EnvPrefix: "STRINGSVC_CLIENT", FlagSet: flag.CommandLine}
+	if err := loader.Load(&cfg, os.Args[1:]); err != nil {
+		if err == config.ErrPrinted {
+			return
+		}
+		log.Fatalln(err)
+	}
+
+	if flag.Arg(0) == "audit" {
+		runAudit(flag.Args()[1:])
+		return
+	}
 
 	// This client supports both HTTP and gRPC transports.
 	// Only one should be needed in production.
 
-	var stringService stringsvc.StringService
+	var stringService stringsvc.Endpoints
 
-	if *httpAddr != "" {
-		stringService = httpclient.New(*httpAddr)
-	} else if *grpcAddr != "" {
-		conn, err := grpc.Dial(*grpcAddr, grpc.WithInsecure(),
-			grpc.WithTimeout(time.Second))
+	tlsConf, err := cfg.tlsConfig()
+	if err != nil {
+		log.Fatalln("TLS error:", err)
+	}
 
+	if cfg.HTTPAddr != "" {
+		if stringService, _, err = cfg.httpEndpoints(cfg.HTTPAddr, tlsConf); err != nil {
+			log.Fatalln("HTTP client error:", err)
+		}
+	} else if cfg.GRPCAddr != "" {
+		endpoints, conn, err := cfg.grpcEndpoints(cfg.GRPCAddr, tlsConf)
 		if err != nil {
 			log.Fatalln("gRPC dial error:", err)
 		}
 		defer conn.Close()
 
-		stringService = grpcclient.New(conn)
+		stringService = endpoints
+	} else if cfg.Discovery.Dir != "" {
+		backend := discovery.Dir{Path: cfg.Discovery.Dir}
+		service, factory := discovery.ServiceGRPC, client.Factory(func(instance string) (stringsvc.Endpoints, io.Closer, error) {
+			return cfg.grpcEndpoints(instance, tlsConf)
+		})
+		if cfg.Discovery.Transport == "http" {
+			service, factory = discovery.ServiceHTTP, func(instance string) (stringsvc.Endpoints, io.Closer, error) {
+				return cfg.httpEndpoints(instance, tlsConf)
+			}
+		}
+		logger := kitlog.NewLogfmtLogger(os.Stderr)
+		instancer := discovery.NewInstancer(backend, service, 5*time.Second, logger)
+		defer instancer.Stop()
+
+		stringService = client.NewBalanced(instancer, factory, cfg.Discovery.Retries, cfg.Discovery.Timeout, logger)
 	}
 
 	args := flag.Args()
 	var cmd string
 
+	if len(args) > 0 && args[0] == "replay" {
+		runReplay(context.Background(), stringService, args[1:])
+		return
+	}
+
 	for len(args) > 0 {
 		cmd, args = pop(args)
 		switch cmd {
 		case "tc":
 			var s string
 			s, args = pop(args)
-			titleCase(context.Background(), stringService, s)
+			titleCase(context.Background(), stringService, stringsvc.TitleCaseOptions{Language: cfg.TC.Language, Style: cfg.TC.Style}, s)
 		case "rw":
 			var s string
 			s, args = pop(args)
-			removeWhitespace(context.Background(), stringService, s)
+			removeWhitespace(context.Background(), stringService, stringsvc.WhitespaceOptions{Mode: cfg.RW.Mode, Class: cfg.RW.Class}, s)
 		case "c":
 			var s string
 			s, args = pop(args)
-			count(context.Background(), stringService, s)
+			count(context.Background(), stringService, cfg.C.Unit, s)
+		case "cc":
+			var style, s string
+			style, args = pop(args)
+			s, args = pop(args)
+			convertCase(context.Background(), stringService, style, s)
+		case "stats":
+			var s string
+			s, args = pop(args)
+			stats(context.Background(), stringService, s)
+		case "norm", "fold":
+			var form, s string
+			form, args = pop(args)
+			s, args = pop(args)
+			normalize(context.Background(), stringService, stringsvc.NormalizeOptions{Form: form, Fold: cmd == "fold"}, s)
+		case "slug":
+			var s string
+			s, args = pop(args)
+			slugify(context.Background(), stringService, stringsvc.SlugOptions{
+				Separator: cfg.Slug.Separator,
+				MaxLength: cfg.Slug.MaxLength,
+				StopWords: cfg.Slug.StopWords,
+				Existing:  cfg.Slug.Existing,
+			}, s)
+		case "sim":
+			var algorithm, a, b string
+			algorithm, args = pop(args)
+			a, args = pop(args)
+			b, args = pop(args)
+			similarity(context.Background(), stringService, algorithm, a, b)
+		case "match", "findall":
+			var pattern, s string
+			pattern, args = pop(args)
+			s, args = pop(args)
+			if cmd == "match" {
+				match(context.Background(), stringService, pattern, s)
+			} else {
+				findAll(context.Background(), stringService, pattern, s)
+			}
+		case "replace":
+			var pattern, template, s string
+			pattern, args = pop(args)
+			template, args = pop(args)
+			s, args = pop(args)
+			replaceAll(context.Background(), stringService, pattern, template, s)
+		case "wrap":
+			var width, s string
+			width, args = pop(args)
+			s, args = pop(args)
+			n, err := strconv.Atoi(width)
+			if err != nil {
+				log.Fatalln("invalid width", width)
+			}
+			wrap(context.Background(), stringService, stringsvc.WrapOptions{
+				Width:     n,
+				LongWords: cfg.Wrap.LongWords,
+				Align:     cfg.Wrap.Align,
+				Indent:    cfg.Wrap.Indent,
+				Hanging:   cfg.Wrap.Hanging,
+			}, s)
+		case "trunc":
+			var limit, s string
+			limit, args = pop(args)
+			s, args = pop(args)
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Fatalln("invalid limit", limit)
+			}
+			truncate(context.Background(), stringService, stringsvc.TruncateOptions{
+				Limit:        n,
+				Unit:         cfg.Trunc.Unit,
+				WordBoundary: cfg.Trunc.WordBoundary,
+				Ellipsis:     cfg.Trunc.Ellipsis,
+			}, s)
+		case "version":
+			version(context.Background(), stringService)
 		default:
 			log.Fatalln("unknown command", cmd)
 		}
 	}
 }
-func count(ctx context.Context, service stringsvc.StringService, s string) {
-	fmt.Println(service.Count(ctx, s))
+func count(ctx context.Context, service stringsvc.StringService, unit, s string) {
+	n, err := service.CountUnit(ctx, s, unit)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(n)
 }
-func removeWhitespace(ctx context.Context, service stringsvc.StringService, s string) {
-	output, err := service.RemoveWhitespace(ctx, s)
+func removeWhitespace(ctx context.Context, service stringsvc.StringService, opts stringsvc.WhitespaceOptions, s string) {
+	output, _, err := service.RemoveWhitespace(ctx, s, opts)
 	if err != nil {
 		println(err.Error())
 		return
 	}
 	fmt.Println(output)
 }
-func titleCase(ctx context.Context, service stringsvc.StringService, s string) {
-	output, err := service.TitleCase(ctx, s)
+func convertCase(ctx context.Context, service stringsvc.StringService, style, s string) {
+	output, err := service.ConvertCase(ctx, s, style)
 	if err != nil {
 		println(err.Error())
 		return
 	}
 	fmt.Println(output)
 }
+func stats(ctx context.Context, service stringsvc.StringService, s string) {
+	v, err := service.Stats(ctx, s)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Printf("bytes=%d runes=%d graphemes=%d words=%d lines=%d sentences=%d width=%d\n",
+		v.Bytes, v.Runes, v.Graphemes, v.Words, v.Lines, v.Sentences, v.Width)
+}
+func normalize(ctx context.Context, service stringsvc.StringService, opts stringsvc.NormalizeOptions, s string) {
+	output, normalized, err := service.Normalize(ctx, s, opts)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+	if normalized {
+		println("already normalized")
+	}
+}
+func slugify(ctx context.Context, service stringsvc.StringService, opts stringsvc.SlugOptions, s string) {
+	output, err := service.Slugify(ctx, s, opts)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+}
+func similarity(ctx context.Context, service stringsvc.StringService, algorithm, a, b string) {
+	res, err := service.Similarity(ctx, a, b, stringsvc.SimilarityOptions{Algorithm: algorithm})
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Printf("distance=%g score=%.4f\n", res.Distance, res.Score)
+}
+func match(ctx context.Context, service stringsvc.StringService, pattern, s string) {
+	output, err := service.Match(ctx, s, pattern)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+}
+func findAll(ctx context.Context, service stringsvc.StringService, pattern, s string) {
+	res, err := service.FindAll(ctx, s, pattern, 0)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	for _, m := range res.Matches {
+		fmt.Printf("%d-%d %q", m.Start, m.End, m.V)
+		for _, g := range m.Groups {
+			fmt.Printf(" %q", g.V)
+		}
+		fmt.Println()
+	}
+	if res.Truncated {
+		println("more matches than the limit")
+	}
+}
+func replaceAll(ctx context.Context, service stringsvc.StringService, pattern, template, s string) {
+	res, err := service.ReplaceAll(ctx, s, pattern, template, 0)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(res.V)
+	if res.Truncated {
+		println("matches past the limit were left as they are")
+	}
+}
+func wrap(ctx context.Context, service stringsvc.StringService, opts stringsvc.WrapOptions, s string) {
+	output, err := service.Wrap(ctx, s, opts)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+}
+func truncate(ctx context.Context, service stringsvc.StringService, opts stringsvc.TruncateOptions, s string) {
+	output, _, err := service.Truncate(ctx, s, opts)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+}
+func version(ctx context.Context, endpoints stringsvc.Endpoints) {
+	fmt.Println("client API version:", stringsvc.APIVersion)
+	served, err := endpoints.Version(ctx)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Printf("server API versions: %d to %d\n", served.MinVersion, served.Version)
+	if err := served.Check(stringsvc.APIVersion); err != nil {
+		println(err.Error())
+	}
+}
+func titleCase(ctx context.Context, service stringsvc.StringService, opts stringsvc.TitleCaseOptions, s string) {
+	output, err := service.TitleCase(ctx, s, opts)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(output)
+}
+
+// httpEndpoints returns the Endpoints of the HTTP server at addr. TLS is
+// not supported over Unix sockets, which have no host name to verify the
+// certificate of the server against.
+func (c Config) httpEndpoints(addr string, tlsConf *tls.Config) (stringsvc.Endpoints, io.Closer, error) {
+	var options []httptransport.ClientOption
+	if c.Tenant != "" {
+		options = append(options, httptransport.ClientBefore(
+			httptransport.SetRequestHeader(stringsvc.TenantHeader, c.Tenant)))
+	}
+	if tlsConf == nil {
+		return httpclient.New(addr, options...), nil, nil
+	}
+	if _, ok := listen.UnixPath(addr); ok {
+		return stringsvc.Endpoints{}, nil, fmt.Errorf("%s: TLS is not supported over Unix sockets", addr)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConf}}
+	options = append(options, httptransport.SetClient(client))
+	return httpclient.New("https://"+strings.TrimPrefix(addr, "https://"), options...), nil, nil
+}
+
+// grpcEndpoints returns the Endpoints of the gRPC server at addr and
+// the connection to close when done.
+func (c Config) grpcEndpoints(addr string, tlsConf *tls.Config) (stringsvc.Endpoints, io.Closer, error) {
+	creds := grpc.WithInsecure()
+	if tlsConf != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConf))
+	}
+	conn, err := grpcclient.Dial(addr, creds,
+		grpc.WithTimeout(time.Second))
+	if err != nil {
+		return stringsvc.Endpoints{}, nil, err
+	}
+	var options []grpctransport.ClientOption
+	if c.Tenant != "" {
+		options = append(options, grpctransport.ClientBefore(
+			grpctransport.SetRequestHeader(stringsvc.TenantHeader, c.Tenant)))
+	}
+	return grpcclient.New(conn, options...), conn, nil
+}
 
 // parse command line argument one by one
 func pop(s []string) (string, []string) {
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+
+	"github.com/afrometal/go-kit-svc/capture"
+	"github.com/afrometal/go-kit-svc/stringsvc"
+)
+
+// runReplay sends the requests captured in the files given in args
+// to endpoints and reports every response that differs from the recorded one.
+// It exits with status 1 if there were any differences.
+func runReplay(ctx context.Context, endpoints stringsvc.Endpoints, args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	verbose := fs.Bool("v", false, "report matching responses too")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: replay [flags] file...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var total, differ, skipped int
+	for _, name := range fs.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = capture.Read(file, func(e capture.Entry) error {
+			total++
+			if e.Redacted {
+				skipped++
+				return nil
+			}
+			diff, err := replay(ctx, endpoints, e)
+			if err != nil {
+				return err
+			}
+			switch {
+			case diff != "":
+				differ++
+				fmt.Printf("DIFF %s %s\n%s\n", e.Method, e.Request, diff)
+			case *verbose:
+				fmt.Printf("OK   %s %s\n", e.Method, e.Request)
+			}
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	fmt.Printf("replayed %d, differ %d, skipped %d redacted\n", total-skipped, differ, skipped)
+	if differ > 0 {
+		os.Exit(1)
+	}
+}
+
+// replay sends the captured request and describes how the response
+// differs from the captured one, if it does.
+func replay(ctx context.Context, endpoints stringsvc.Endpoints, e capture.Entry) (string, error) {
+	ep := endpoints.Endpoint(e.Method)
+	if ep == nil {
+		return "", fmt.Errorf("unknown method %q", e.Method)
+	}
+	request, err := stringsvc.DecodeJSONRequest(e.Method, e.Request)
+	if err != nil {
+		return "", err
+	}
+	response, err := ep(ctx, request)
+	if err != nil {
+		if err.Error() != e.Error {
+			return fmt.Sprintf("  want error %q\n  got error  %q", e.Error, err.Error()), nil
+		}
+		return "", nil
+	}
+	if e.Error != "" {
+		return fmt.Sprintf("  want error %q\n  got        %s", e.Error, mustJSON(response)), nil
+	}
+
+	got := mustJSON(response)
+	var want, have interface{}
+	if err := json.Unmarshal(e.Response, &want); err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(got, &have); err != nil {
+		return "", err
+	}
+	if !reflect.DeepEqual(want, have) {
+		return fmt.Sprintf("  want %s\n  got  %s", e.Response, got), nil
+	}
+	return "", nil
+}
+
+func mustJSON(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return b
+}
@@ -0,0 +1,375 @@
+// Package config loads configuration structs from layered sources: defaults
+// set by the caller, a YAML file, environment variables and command line
+// flags, each one overriding the previous.
+//
+// Settings are the exported fields of the struct, named by their yaml tags.
+// Nested structs group settings, so field max_size of the audit section is
+// set with the audit.max_size key of the file, the PREFIX_AUDIT_MAX_SIZE
+// environment variable and the -audit-max-size flag. Further tags are:
+//
+//	usage:"..."    help text of the flag
+//	flag:"name"    flag name other than the derived one, "-" for none
+//	env:"NAME"     variable name without prefix other than the derived one, "-" for none
+//	secret:"true"  value is masked when the configuration is printed
+//
+// Strings, booleans, numbers, durations, string slices and implementations
+// of encoding.TextUnmarshaler can be set from variables and flags. Slices are
+// comma-separated in variables and given in repeated flags. Other settings,
+// such as lists of structs, can only be set in the file.
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrPrinted is returned by Load after the effective configuration
+// was printed as requested with the -print-config flag.
+var ErrPrinted = errors.New("configuration printed")
+
+// Validator is implemented by configurations that check their own settings.
+type Validator interface {
+	Validate() error
+}
+
+// Loader loads configuration structs.
+type Loader struct {
+	// EnvPrefix is prepended to the names of environment variables, e.g. STRINGSVC.
+	EnvPrefix string
+	// FlagSet settings flags are defined in, -config and -print-config included.
+	FlagSet *flag.FlagSet
+}
+
+// Load fills cfg, a pointer to a struct holding defaults, from the file
+// named with the -config flag or the PREFIX_CONFIG variable, then from
+// environment variables and finally from flags parsed from args.
+// The result is validated if cfg implements Validator.
+func (l Loader) Load(cfg interface{}, args []string) error {
+	settings, err := fields(cfg)
+	if err != nil {
+		return err
+	}
+	fs := l.FlagSet
+	path := fs.String("config", os.Getenv(l.env("CONFIG")),
+		"YAML configuration file, also "+l.env("CONFIG"))
+	print := fs.Bool("print-config", false,
+		"print the effective configuration and exit")
+	values := map[string]*flagValue{}
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		v := &flagValue{setting: s}
+		values[s.flag] = v
+		fs.Var(v, s.flag, s.help)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *path != "" {
+		b, err := ioutil.ReadFile(*path)
+		if err != nil {
+			return err
+		}
+		if err := yaml.UnmarshalStrict(b, cfg); err != nil {
+			return fmt.Errorf("%s: %v", *path, err)
+		}
+	}
+	for _, s := range settings {
+		if s.env == "" {
+			continue
+		}
+		name := l.env(s.env)
+		if v, ok := os.LookupEnv(name); ok {
+			if err := s.setEnv(v); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+	}
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if v, ok := values[f.Name]; ok && flagErr == nil {
+			flagErr = v.apply()
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+
+	if v, ok := cfg.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+	}
+	if *print {
+		if err := Print(os.Stdout, cfg); err != nil {
+			return err
+		}
+		return ErrPrinted
+	}
+	return nil
+}
+
+func (l Loader) env(name string) string {
+	if l.EnvPrefix == "" {
+		return name
+	}
+	return l.EnvPrefix + "_" + name
+}
+
+// Print writes cfg as YAML with secret settings masked.
+func Print(w io.Writer, cfg interface{}) error {
+	masked := reflect.New(reflect.TypeOf(cfg).Elem())
+	masked.Elem().Set(reflect.ValueOf(cfg).Elem())
+	settings, err := fields(masked.Interface())
+	if err != nil {
+		return err
+	}
+	for _, s := range settings {
+		if s.secret && !s.value.IsZero() {
+			s.mask()
+		}
+	}
+	b, err := yaml.Marshal(masked.Interface())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Errors collects validation errors of several settings.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Err returns e if there are any errors, nil otherwise.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Check adds an error for the setting at key if ok is false.
+func (e *Errors) Check(ok bool, key, format string, args ...interface{}) {
+	if !ok {
+		*e = append(*e, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+// setting is a single configurable field.
+type setting struct {
+	flag   string
+	env    string
+	help   string
+	secret bool
+	value  reflect.Value
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// fields returns the settings of the struct pointed to by cfg.
+func fields(cfg interface{}) ([]setting, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: %T is not a pointer to struct", cfg)
+	}
+	var settings []setting
+	var walk func(v reflect.Value, path []string)
+	walk = func(v reflect.Value, path []string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if f.PkgPath != "" || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			p := append(append([]string{}, path...), name)
+			if f.Type.Kind() == reflect.Struct && !settable(f.Type) {
+				walk(v.Field(i), p)
+				continue
+			}
+			s := setting{
+				flag:   strings.Replace(strings.Join(p, "-"), "_", "-", -1),
+				env:    strings.ToUpper(strings.Join(p, "_")),
+				help:   f.Tag.Get("usage"),
+				secret: f.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			}
+			if tag, ok := f.Tag.Lookup("flag"); ok {
+				s.flag = tag
+			}
+			if tag, ok := f.Tag.Lookup("env"); ok {
+				s.env = tag
+			}
+			if !settable(f.Type) {
+				s.flag, s.env = "", ""
+			}
+			if s.flag == "-" {
+				s.flag = ""
+			}
+			if s.env == "-" {
+				s.env = ""
+			}
+			settings = append(settings, s)
+		}
+	}
+	walk(v.Elem(), nil)
+	return settings, nil
+}
+
+func settable(t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// setEnv sets the value from an environment variable,
+// slices are comma-separated.
+func (s setting) setEnv(v string) error {
+	if s.value.Kind() == reflect.Slice && s.value.Type().Elem().Kind() == reflect.String {
+		var items []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(items))
+		return nil
+	}
+	return s.set(v)
+}
+
+// set parses v into the value of a scalar setting.
+func (s setting) set(v string) error {
+	if u, ok := s.value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(v))
+	}
+	if s.value.Type() == durationType {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(int64(d))
+		return nil
+	}
+	switch s.value.Kind() {
+	case reflect.String:
+		s.value.SetString(v)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		s.value.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		s.value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", s.value.Type())
+	}
+	return nil
+}
+
+func (s setting) mask() {
+	switch s.value.Kind() {
+	case reflect.String:
+		s.value.SetString("********")
+	case reflect.Slice:
+		masked := make([]string, s.value.Len())
+		for i := range masked {
+			masked[i] = "********"
+		}
+		s.value.Set(reflect.ValueOf(masked))
+	}
+}
+
+// flagValue is a flag.Value collecting the raw values of a setting, which
+// are applied after the file and environment variables were loaded.
+type flagValue struct {
+	setting
+	raw []string
+}
+
+func (v *flagValue) String() string {
+	if v == nil || !v.value.IsValid() {
+		return ""
+	}
+	if v.multi() {
+		return strings.Join(v.value.Interface().([]string), ",")
+	}
+	return fmt.Sprint(v.value.Interface())
+}
+
+func (v *flagValue) Set(s string) error {
+	if !v.multi() {
+		v.raw = []string{s}
+		// Parse into a scratch value to report errors while parsing flags.
+		scratch := v.setting
+		scratch.value = reflect.New(v.value.Type()).Elem()
+		return scratch.set(s)
+	}
+	v.raw = append(v.raw, s)
+	return nil
+}
+
+// multi reports whether the flag may be repeated.
+func (v *flagValue) multi() bool {
+	return v.value.Kind() == reflect.Slice && v.value.Type().Elem().Kind() == reflect.String
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.value.Kind() == reflect.Bool
+}
+
+func (v *flagValue) apply() error {
+	if v.multi() {
+		v.value.Set(reflect.ValueOf(append([]string{}, v.raw...)))
+		return nil
+	}
+	if err := v.set(v.raw[0]); err != nil {
+		return fmt.Errorf("-%s: %v", v.flag, err)
+	}
+	return nil
+}
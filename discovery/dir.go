@@ -0,0 +1,122 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Dir is a Backend keeping every instance as a JSON file in a directory
+// shared by the registering and discovering processes. Expired files are
+// ignored and removed by the next registration.
+type Dir struct {
+	Path string
+}
+
+// Register implements Backend. The file is replaced atomically.
+func (d Dir) Register(_ context.Context, inst Instance, ttl time.Duration) error {
+	if err := os.MkdirAll(d.Path, 0755); err != nil {
+		return err
+	}
+	inst.Expires = time.Now().Add(ttl).UTC()
+	b, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(d.Path, ".register-")
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), d.file(inst.Service, inst.ID)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	d.prune()
+	return nil
+}
+
+// Deregister implements Backend.
+func (d Dir) Deregister(_ context.Context, service, id string) error {
+	err := os.Remove(d.file(service, id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Instances implements Backend.
+func (d Dir) Instances(_ context.Context, service string) ([]Instance, error) {
+	all, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var instances []Instance
+	for _, inst := range all {
+		if inst.Service == service && inst.Expires.After(now) {
+			instances = append(instances, inst)
+		}
+	}
+	return instances, nil
+}
+
+// read returns all instances in the directory, which may not exist yet.
+func (d Dir) read() ([]Instance, error) {
+	paths, err := filepath.Glob(filepath.Join(d.Path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var instances []Instance
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue // deregistered meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		var inst Instance
+		if err := json.Unmarshal(b, &inst); err != nil {
+			continue // not an instance
+		}
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+// prune removes the files of expired instances.
+func (d Dir) prune() {
+	all, err := d.read()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for _, inst := range all {
+		if inst.Expires.Before(now) {
+			os.Remove(d.file(inst.Service, inst.ID))
+		}
+	}
+}
+
+func (d Dir) file(service, id string) string {
+	name := service + "_" + strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(id)
+	return filepath.Join(d.Path, name+".json")
+}
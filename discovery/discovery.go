@@ -0,0 +1,249 @@
+// Package discovery registers instances of the service in a registry and
+// discovers them again, adapting pluggable registry backends to go-kit's
+// sd.Registrar and sd.Instancer. The Dir backend keeps the registry in a
+// directory, usable locally and in tests; Consul, etcd and others plug in
+// by implementing Backend with their clients.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-kit/kit/sd"
+)
+
+// Service names the string service registers its transports under.
+const (
+	ServiceHTTP = "stringsvc-http"
+	ServiceGRPC = "stringsvc-grpc"
+)
+
+// Instance is a registered instance of a service.
+type Instance struct {
+	ID       string            `json:"id"`
+	Service  string            `json:"service"`
+	Address  string            `json:"address"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Expires is when the registration lapses unless it is renewed.
+	Expires time.Time `json:"expires"`
+}
+
+// Backend is a service registry.
+type Backend interface {
+	// Register adds the instance or renews its registration for ttl.
+	Register(ctx context.Context, inst Instance, ttl time.Duration) error
+	// Deregister removes the instance of the service with the ID.
+	Deregister(ctx context.Context, service, id string) error
+	// Instances returns the live instances of the service.
+	Instances(ctx context.Context, service string) ([]Instance, error)
+}
+
+// Registrar is a sd.Registrar keeping an instance registered by renewing
+// it every half of its TTL until it is deregistered.
+type Registrar struct {
+	backend Backend
+	inst    Instance
+	ttl     time.Duration
+	logger  log.Logger
+
+	mtx  sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRegistrar returns a Registrar of the instance.
+func NewRegistrar(b Backend, inst Instance, ttl time.Duration, logger log.Logger) *Registrar {
+	return &Registrar{
+		backend: b,
+		inst:    inst,
+		ttl:     ttl,
+		logger:  log.With(logger, "service", inst.Service, "id", inst.ID),
+	}
+}
+
+// Register implements sd.Registrar.
+func (r *Registrar) Register() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.renew()
+	r.logger.Log("action", "register", "addr", r.inst.Address)
+	r.stop, r.done = make(chan struct{}), make(chan struct{})
+	go r.heartbeat(r.stop, r.done)
+}
+
+// Deregister implements sd.Registrar.
+func (r *Registrar) Deregister() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.stop == nil {
+		return
+	}
+	close(r.stop)
+	<-r.done
+	r.stop, r.done = nil, nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.ttl)
+	defer cancel()
+	if err := r.backend.Deregister(ctx, r.inst.Service, r.inst.ID); err != nil {
+		level.Error(r.logger).Log("action", "deregister", "err", err)
+		return
+	}
+	r.logger.Log("action", "deregister")
+}
+
+func (r *Registrar) heartbeat(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(r.ttl / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.renew()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (r *Registrar) renew() {
+	ctx, cancel := context.WithTimeout(context.Background(), r.ttl/2)
+	defer cancel()
+	if err := r.backend.Register(ctx, r.inst, r.ttl); err != nil {
+		level.Error(r.logger).Log("action", "renew", "err", err)
+	}
+}
+
+// Instancer is a sd.Instancer polling the backend for the instances
+// of a service and notifying observers when they change.
+type Instancer struct {
+	backend  Backend
+	service  string
+	interval time.Duration
+	logger   log.Logger
+
+	mtx       sync.Mutex
+	state     sd.Event
+	observers map[chan<- sd.Event]struct{}
+	stop      chan struct{}
+}
+
+// NewInstancer returns an Instancer of the service polling every interval.
+// The first poll happens before it returns.
+func NewInstancer(b Backend, service string, interval time.Duration, logger log.Logger) *Instancer {
+	s := &Instancer{
+		backend:   b,
+		service:   service,
+		interval:  interval,
+		logger:    log.With(logger, "service", service),
+		observers: map[chan<- sd.Event]struct{}{},
+		stop:      make(chan struct{}),
+	}
+	s.update(s.poll())
+	go s.loop()
+	return s
+}
+
+func (s *Instancer) loop() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.update(s.poll())
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Instancer) poll() sd.Event {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+	instances, err := s.backend.Instances(ctx, s.service)
+	if err != nil {
+		level.Warn(s.logger).Log("during", "discovery", "err", err)
+		return sd.Event{Err: err}
+	}
+	addrs := make([]string, len(instances))
+	for i, inst := range instances {
+		addrs[i] = inst.Address
+	}
+	sort.Strings(addrs)
+	return sd.Event{Instances: addrs}
+}
+
+// update broadcasts the event to the observers if the state changed.
+func (s *Instancer) update(e sd.Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if reflect.DeepEqual(s.state, e) {
+		return
+	}
+	s.state = e
+	for ch := range s.observers {
+		ch <- e
+	}
+}
+
+// Register implements sd.Instancer.
+func (s *Instancer) Register(ch chan<- sd.Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.observers[ch] = struct{}{}
+	ch <- s.state
+}
+
+// Deregister implements sd.Instancer.
+func (s *Instancer) Deregister(ch chan<- sd.Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.observers, ch)
+}
+
+// Stop implements sd.Instancer.
+func (s *Instancer) Stop() {
+	close(s.stop)
+}
+
+// DefaultID returns an instance ID made of the host name and process ID.
+func DefaultID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Advertise returns the address to register for a listener of addr. Host
+// and port addresses without a host, or with an unspecified one, get the
+// host name. Other addresses, such as Unix sockets, are advertised as is.
+func Advertise(addr string) (string, error) {
+	if strings.HasPrefix(addr, "systemd:") {
+		return "", fmt.Errorf("address of %s cannot be derived, set it explicitly", addr)
+	}
+	if strings.Contains(addr, "://") {
+		return addr, nil
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		if host, err = os.Hostname(); err != nil {
+			return "", err
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
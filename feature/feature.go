@@ -0,0 +1,183 @@
+// Package feature evaluates per-method feature flags, switching methods on
+// and off globally, per tenant or for a percentage of callers. Flags can be
+// replaced while the service runs.
+package feature
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// Flag decides whether a method is enabled.
+//
+// A tenant listed in Tenants is switched by its entry. For other callers
+// the method is off if Enabled is false, and otherwise on for Percentage
+// percent of them, chosen by a hash of the tenant, or of the caller
+// without a tenant, so that the same ones keep getting it. Anonymous
+// calls are chosen at random.
+type Flag struct {
+	Enabled    bool            `yaml:"enabled" json:"enabled"`
+	Percentage float64         `yaml:"percentage" json:"percentage"`
+	Tenants    map[string]bool `yaml:"tenants,omitempty" json:"tenants,omitempty"`
+}
+
+// DefaultFlag enables a method for everyone. Methods without a flag
+// and omitted fields of flags take its values.
+var DefaultFlag = Flag{Enabled: true, Percentage: 100}
+
+// UnmarshalYAML implements yaml.Unmarshaler, defaulting omitted fields
+// to those of DefaultFlag.
+func (f *Flag) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Flag
+	v := plain(DefaultFlag)
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*f = Flag(v)
+	return nil
+}
+
+// Validate checks that the percentage is between 0 and 100.
+func (f Flag) Validate() error {
+	if f.Percentage < 0 || f.Percentage > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100, got %v", f.Percentage)
+	}
+	return nil
+}
+
+// Decision is the outcome of evaluating a flag, with the reason for it.
+type Decision struct {
+	Enabled bool
+	Reason  string
+}
+
+// Reasons of decisions.
+const (
+	ReasonDefault    = "default"
+	ReasonTenant     = "tenant"
+	ReasonGlobal     = "global"
+	ReasonPercentage = "percentage"
+)
+
+// Evaluate decides whether the method is enabled for a call of caller
+// on behalf of tenant, either of which may be empty.
+func (f Flag) Evaluate(method, tenant, caller string) Decision {
+	if on, ok := f.Tenants[tenant]; ok && tenant != "" {
+		return Decision{on, ReasonTenant}
+	}
+	if !f.Enabled {
+		return Decision{false, ReasonGlobal}
+	}
+	if f.Percentage >= 100 {
+		return Decision{true, ReasonGlobal}
+	}
+	key := tenant
+	if key == "" {
+		key = caller
+	}
+	var bucket float64
+	if key == "" {
+		bucket = rand.Float64() * 100
+	} else {
+		h := fnv.New32a()
+		h.Write([]byte(method + "/" + key))
+		bucket = float64(h.Sum32()%10000) / 100
+	}
+	return Decision{bucket < f.Percentage, ReasonPercentage}
+}
+
+// Flags holds the flags of all methods and counts their decisions.
+// It is safe for concurrent use.
+type Flags struct {
+	current atomic.Value // map[string]Flag
+
+	mtx    sync.Mutex
+	counts map[string]*Counts
+}
+
+// Counts of calls of a method by decision since the service started.
+type Counts struct {
+	Enabled  uint64 `json:"enabled"`
+	Disabled uint64 `json:"disabled"`
+}
+
+// New returns Flags holding the validated flags of methods.
+func New(flags map[string]Flag) (*Flags, error) {
+	f := &Flags{counts: map[string]*Counts{}}
+	f.current.Store(map[string]Flag{})
+	if err := f.Set(flags); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Set validates and replaces the flags of all methods.
+func (f *Flags) Set(flags map[string]Flag) error {
+	for m, flag := range flags {
+		if err := flag.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", m, err)
+		}
+	}
+	copied := make(map[string]Flag, len(flags))
+	for m, flag := range flags {
+		copied[m] = flag
+	}
+	f.current.Store(copied)
+	return nil
+}
+
+// Flag returns the flag of the method.
+func (f *Flags) Flag(method string) Flag {
+	if flag, ok := f.current.Load().(map[string]Flag)[method]; ok {
+		return flag
+	}
+	return DefaultFlag
+}
+
+// Evaluate decides whether the method is enabled for a call
+// and counts the decision.
+func (f *Flags) Evaluate(method, tenant, caller string) Decision {
+	flag, ok := f.current.Load().(map[string]Flag)[method]
+	d := Decision{true, ReasonDefault}
+	if ok {
+		d = flag.Evaluate(method, tenant, caller)
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	c, ok := f.counts[method]
+	if !ok {
+		c = &Counts{}
+		f.counts[method] = c
+	}
+	if d.Enabled {
+		c.Enabled++
+	} else {
+		c.Disabled++
+	}
+	return d
+}
+
+// State of the flag of a method, as shown on the admin endpoint.
+type State struct {
+	Method string `json:"method"`
+	Flag
+	Counts Counts `json:"counts"`
+}
+
+// States returns the state of the flags of methods.
+func (f *Flags) States(methods []string) []State {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	states := make([]State, len(methods))
+	for i, m := range methods {
+		states[i] = State{Method: m, Flag: f.Flag(m)}
+		if c, ok := f.counts[m]; ok {
+			states[i].Counts = *c
+		}
+	}
+	return states
+}
@@ -0,0 +1,32 @@
+package feature
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler returns a handler serving the JSON-encoded state of the flags
+// of methods. A tenant query parameter adds the decision for that tenant.
+func (f *Flags) Handler(methods []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type state struct {
+			State
+			Tenant *bool `json:"enabled_for_tenant,omitempty"`
+		}
+		tenant := r.URL.Query().Get("tenant")
+		var states []state
+		for _, s := range f.States(methods) {
+			st := state{State: s}
+			if tenant != "" {
+				on := s.Flag.Evaluate(s.Method, tenant, "").Enabled
+				st.Tenant = &on
+			}
+			states = append(states, st)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(states)
+	})
+}
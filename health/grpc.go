@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// MinWatchInterval is the shortest interval Watch streams re-evaluate the
+// readiness at.
+const MinWatchInterval = 100 * time.Millisecond
+
+// NewGRPCServer returns a gRPC health service answering with the readiness
+// of the registry for the overall server ("") and for each of services.
+// Watch streams re-evaluate the readiness every interval, at least
+// MinWatchInterval.
+func NewGRPCServer(r *Registry, interval time.Duration, services ...string) healthpb.HealthServer {
+	if interval < MinWatchInterval {
+		interval = MinWatchInterval
+	}
+	known := map[string]bool{"": true}
+	for _, s := range services {
+		known[s] = true
+	}
+	return &grpcServer{registry: r, interval: interval, services: known}
+}
+
+type grpcServer struct {
+	registry *Registry
+	interval time.Duration
+	services map[string]bool
+}
+
+func (s *grpcServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !s.services[req.Service] {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+func (s *grpcServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		st := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if s.services[req.Service] {
+			st = s.status(ctx)
+		}
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *grpcServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.registry.Readiness(ctx).Status == StatusFail {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
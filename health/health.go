@@ -0,0 +1,213 @@
+// Package health aggregates named checks registered by the components of
+// the service into liveness and readiness reports. Check results are cached
+// so that frequent probes do not stampede the checked dependencies.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Statuses of checks and reports.
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// Checker checks a single component, a nil error means it is healthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to use an ordinary function as a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check implements Checker.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Option configures a registered check.
+type Option func(*check)
+
+// Timeout limits the duration of a single run of the check, 1s by default.
+func Timeout(d time.Duration) Option {
+	return func(c *check) { c.timeout = d }
+}
+
+// NonCritical makes a failing check only degrade the report to warn.
+func NonCritical() Option {
+	return func(c *check) { c.critical = false }
+}
+
+// Liveness makes the check part of the liveness report as well. Checks are
+// only part of the readiness report by default, as a failed liveness probe
+// gets the process restarted.
+func Liveness() Option {
+	return func(c *check) { c.liveness = true }
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	TookMs    float64   `json:"took_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report aggregates the results of checks. It fails if any critical
+// check failed and warns if any non-critical one did.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Registry holds named checks. It is safe for concurrent use.
+type Registry struct {
+	mtx    sync.Mutex
+	ttl    time.Duration
+	checks map[string]*check
+}
+
+// NewRegistry returns an empty Registry caching results for ttl.
+func NewRegistry(ttl time.Duration) *Registry {
+	return &Registry{ttl: ttl, checks: map[string]*check{}}
+}
+
+// SetTTL changes the duration results are cached for.
+func (r *Registry) SetTTL(ttl time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.ttl = ttl
+}
+
+// Register adds the named check, by default a critical readiness check.
+// Registering a name again replaces the previous check.
+func (r *Registry) Register(name string, c Checker, opts ...Option) {
+	chk := &check{
+		name:     name,
+		checker:  c,
+		timeout:  time.Second,
+		critical: true,
+	}
+	for _, opt := range opts {
+		opt(chk)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.checks[name] = chk
+}
+
+// Liveness runs the liveness checks.
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, func(c *check) bool { return c.liveness })
+}
+
+// Readiness runs all checks.
+func (r *Registry) Readiness(ctx context.Context) Report {
+	return r.run(ctx, func(*check) bool { return true })
+}
+
+func (r *Registry) run(ctx context.Context, include func(*check) bool) Report {
+	r.mtx.Lock()
+	ttl := r.ttl
+	var checks []*check
+	for _, c := range r.checks {
+		if include(c) {
+			checks = append(checks, c)
+		}
+	}
+	r.mtx.Unlock()
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.result(ctx, ttl)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusPass, Checks: results}
+	for _, res := range results {
+		switch {
+		case res.Status == StatusFail && res.Critical:
+			report.Status = StatusFail
+		case res.Status == StatusFail && report.Status == StatusPass:
+			report.Status = StatusWarn
+		}
+	}
+	return report
+}
+
+type check struct {
+	name     string
+	checker  Checker
+	timeout  time.Duration
+	critical bool
+	liveness bool
+
+	mtx  sync.Mutex
+	last Result
+}
+
+// result returns the cached result if it is fresh, runs the check otherwise.
+// Concurrent callers wait for a single run. Results of runs cut short
+// by the caller going away are not cached.
+func (c *check) result(parent context.Context, ttl time.Duration) Result {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if !c.last.CheckedAt.IsZero() && time.Since(c.last.CheckedAt) < ttl {
+		return c.last
+	}
+
+	ctx, cancel := context.WithTimeout(parent, c.timeout)
+	defer cancel()
+
+	begin := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errc <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		errc <- c.checker.Check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+		if err == context.DeadlineExceeded {
+			err = errors.New("timed out")
+		}
+	}
+
+	res := Result{
+		Name:      c.name,
+		Status:    StatusPass,
+		Critical:  c.critical,
+		TookMs:    float64(time.Since(begin)) / float64(time.Millisecond),
+		CheckedAt: begin.UTC(),
+	}
+	if err != nil {
+		res.Status, res.Error = StatusFail, err.Error()
+	}
+	if parent.Err() == nil {
+		c.last = res
+	}
+	return res
+}
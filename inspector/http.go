@@ -0,0 +1,79 @@
+package inspector
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ServeHTTP renders the buckets of all methods and the calls of the bucket
+// selected with the method and bucket query parameters. The page is HTML,
+// or JSON with format=json or an application/json Accept header.
+func (in *Inspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	method, bucket := q.Get("method"), q.Get("bucket")
+	if bucket == "" {
+		bucket = BucketRecent
+	}
+
+	page := pageData{Method: method, Bucket: bucket, Buckets: Buckets}
+	for _, m := range in.Methods() {
+		page.Summary = append(page.Summary, methodSummary{Method: m, Counts: in.Counts(m)})
+	}
+	if method != "" {
+		page.Calls = in.Calls(method, bucket)
+	}
+
+	if q.Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(page)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pageTemplate.Execute(w, page)
+}
+
+type pageData struct {
+	Method  string          `json:"method,omitempty"`
+	Bucket  string          `json:"bucket"`
+	Buckets []string        `json:"-"`
+	Summary []methodSummary `json:"summary"`
+	Calls   []Call          `json:"calls,omitempty"`
+}
+
+type methodSummary struct {
+	Method string         `json:"method"`
+	Counts map[string]int `json:"counts"`
+}
+
+var pageTemplate = template.Must(template.New("requests").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>/debug/requests</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { padding: 2px 8px; text-align: left; vertical-align: top; }
+tr:nth-child(even) { background: #eee; }
+.failed { color: #c00; }
+</style>
+</head>
+<body>
+<h1>/debug/requests</h1>
+<table>
+<tr><th>Method</th>{{range .Buckets}}<th>{{.}}</th>{{end}}</tr>
+{{range $s := .Summary}}<tr><td>{{$s.Method}}</td>{{range $b := $.Buckets}}<td><a href="?method={{$s.Method}}&amp;bucket={{$b}}">{{index $s.Counts $b}}</a></td>{{end}}</tr>
+{{end}}</table>
+{{if .Method}}
+<h2>{{.Method}}: {{.Bucket}}</h2>
+<table>
+<tr><th>Start</th><th>Took</th><th>Transport</th><th>Caller</th><th>Remote</th><th>Input</th><th>Error</th></tr>
+{{range .Calls}}<tr{{if .Failed}} class="failed"{{end}}><td>{{.Start.Format "2006-01-02 15:04:05.000000"}}</td><td>{{.Took}}</td><td>{{.Transport}}</td><td>{{.Caller}}</td><td>{{.Remote}}</td><td><code>{{printf "%q" .Input}}{{if .Truncated}}&hellip;{{end}}</code></td><td>{{.Err}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,178 @@
+// Package inspector keeps the most recent calls of every method in memory,
+// together with separate buckets of slow and failed calls, so they can be
+// looked at during an incident without digging through logs.
+package inspector
+
+import (
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Buckets calls are kept in.
+const (
+	BucketRecent = "recent"
+	BucketSlow   = "slow"
+	BucketFailed = "failed"
+)
+
+// Buckets lists all buckets in display order.
+var Buckets = []string{BucketRecent, BucketSlow, BucketFailed}
+
+// Call is a single recorded call.
+type Call struct {
+	Method    string        `json:"method"`
+	Start     time.Time     `json:"start"`
+	Took      time.Duration `json:"took_ns"`
+	Input     string        `json:"input"`
+	Truncated bool          `json:"truncated,omitempty"`
+	Caller    string        `json:"caller,omitempty"`
+	Remote    string        `json:"remote,omitempty"`
+	Transport string        `json:"transport,omitempty"`
+	Err       string        `json:"err,omitempty"`
+}
+
+// Failed reports whether the call returned an error.
+func (c Call) Failed() bool { return c.Err != "" }
+
+// Options of an Inspector.
+type Options struct {
+	// Size is the number of calls kept in each bucket of every method.
+	Size int
+	// Slow is the duration above which calls are put in the slow bucket.
+	Slow time.Duration
+	// MaxInput is the number of bytes of input kept for each call.
+	MaxInput int
+}
+
+// Inspector keeps recent calls in bounded ring buffers per method and bucket.
+// It is safe for concurrent use.
+type Inspector struct {
+	opts Options
+
+	mtx     sync.Mutex
+	methods map[string]map[string]*ring
+}
+
+// New returns an Inspector with the options, zero options are set to defaults.
+func New(opts Options) *Inspector {
+	if opts.Size <= 0 {
+		opts.Size = 200
+	}
+	if opts.Slow <= 0 {
+		opts.Slow = 100 * time.Millisecond
+	}
+	if opts.MaxInput <= 0 {
+		opts.MaxInput = 128
+	}
+	return &Inspector{opts: opts, methods: map[string]map[string]*ring{}}
+}
+
+// Record adds the call to the recent bucket of its method,
+// and to the slow or failed buckets if it was one.
+func (in *Inspector) Record(c Call) {
+	c.Input, c.Truncated = truncate(c.Input, in.opts.MaxInput)
+
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+
+	buckets, ok := in.methods[c.Method]
+	if !ok {
+		buckets = map[string]*ring{}
+		for _, b := range Buckets {
+			buckets[b] = newRing(in.opts.Size)
+		}
+		in.methods[c.Method] = buckets
+	}
+	buckets[BucketRecent].add(c)
+	if c.Took >= in.opts.Slow {
+		buckets[BucketSlow].add(c)
+	}
+	if c.Failed() {
+		buckets[BucketFailed].add(c)
+	}
+}
+
+// Methods returns the names of methods with recorded calls, sorted.
+func (in *Inspector) Methods() []string {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+
+	var methods []string
+	for m := range in.methods {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Calls returns the calls kept in the bucket of the method, newest first.
+func (in *Inspector) Calls(method, bucket string) []Call {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+
+	buckets, ok := in.methods[method]
+	if !ok || buckets[bucket] == nil {
+		return nil
+	}
+	return buckets[bucket].calls()
+}
+
+// Counts returns the number of calls kept in each bucket of the method.
+func (in *Inspector) Counts(method string) map[string]int {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+
+	counts := map[string]int{}
+	for b, r := range in.methods[method] {
+		counts[b] = r.len()
+	}
+	return counts
+}
+
+// truncate shortens s to at most n bytes without splitting a rune.
+func truncate(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], true
+}
+
+// ring is a bounded buffer overwriting its oldest calls.
+type ring struct {
+	buf  []Call
+	next int
+	full bool
+}
+
+func newRing(n int) *ring {
+	return &ring{buf: make([]Call, n)}
+}
+
+func (r *ring) add(c Call) {
+	r.buf[r.next] = c
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *ring) len() int {
+	if r.full {
+		return len(r.buf)
+	}
+	return r.next
+}
+
+func (r *ring) calls() []Call {
+	n := r.len()
+	calls := make([]Call, 0, n)
+	for i := 1; i <= n; i++ {
+		calls = append(calls, r.buf[(r.next-i+len(r.buf))%len(r.buf)])
+	}
+	return calls
+}
@@ -0,0 +1,176 @@
+// Package listen creates the listeners of the service from addresses:
+//
+//	host:port            TCP
+//	unix:///run/svc.sock Unix domain socket
+//	systemd:name         socket passed by systemd socket activation, by
+//	                     FileDescriptorName or by index such as systemd:0
+package listen
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Address schemes other than TCP.
+const (
+	UnixScheme    = "unix://"
+	SystemdScheme = "systemd:"
+)
+
+// Options of listeners.
+type Options struct {
+	// Mode is the permission of Unix socket files, 0777 less umask if 0.
+	Mode os.FileMode
+}
+
+// FileMode is an os.FileMode that (un)marshals as an octal string such as "0660".
+type FileMode os.FileMode
+
+func (m FileMode) String() string {
+	return fmt.Sprintf("%#o", uint32(m))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m FileMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *FileMode) UnmarshalText(b []byte) error {
+	v, err := strconv.ParseUint(string(b), 8, 32)
+	if err != nil {
+		return fmt.Errorf("invalid file mode %q", b)
+	}
+	if os.FileMode(v)&^os.ModePerm != 0 {
+		return fmt.Errorf("file mode %q has bits other than permissions", b)
+	}
+	*m = FileMode(v)
+	return nil
+}
+
+// Listen returns a listener of addr.
+func Listen(addr string, opts Options) (net.Listener, error) {
+	if path, ok := UnixPath(addr); ok {
+		return listenUnix(path, opts.Mode)
+	}
+	if strings.HasPrefix(addr, SystemdScheme) {
+		return activated(strings.TrimPrefix(addr, SystemdScheme))
+	}
+	return net.Listen("tcp", addr)
+}
+
+// UnixPath returns the socket path of a unix:// address.
+func UnixPath(addr string) (string, bool) {
+	if !strings.HasPrefix(addr, UnixScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(addr, UnixScheme), true
+}
+
+// listenUnix listens on the socket at path, replacing a stale socket file
+// left behind by a process that did not shut down. The file is removed
+// when the listener is closed.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			ln.Close()
+			return nil, err
+		}
+	}
+	return ln, nil
+}
+
+// Systemd passes sockets starting at this file descriptor.
+const listenFDsStart = 3
+
+var systemd struct {
+	sync.Mutex
+	inherited bool
+	files     []*os.File
+	err       error
+}
+
+// activated returns the listener of the socket passed by systemd under
+// name, or at the index name parses as. Each socket is returned once.
+func activated(name string) (net.Listener, error) {
+	systemd.Lock()
+	defer systemd.Unlock()
+	if !systemd.inherited {
+		systemd.files, systemd.err = inherit()
+		systemd.inherited = true
+	}
+	if systemd.err != nil {
+		return nil, systemd.err
+	}
+	for i, f := range systemd.files {
+		if f == nil || (f.Name() != name && strconv.Itoa(i) != name) {
+			continue
+		}
+		systemd.files[i] = nil
+		defer f.Close()
+		return net.FileListener(f)
+	}
+	return nil, fmt.Errorf("no socket %q passed by systemd", name)
+}
+
+// inherit returns the files of the sockets passed by systemd according to
+// the LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES variables, which are unset
+// so that child processes do not inherit them.
+func inherit() ([]*os.File, error) {
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+	defer os.Unsetenv("LISTEN_FDNAMES")
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.New("no sockets passed by systemd")
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return nil, errors.New("no sockets passed by systemd")
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	files := make([]*os.File, n)
+	for i := range files {
+		fd := listenFDsStart + i
+		syscall.CloseOnExec(fd)
+		name := strconv.Itoa(i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		files[i] = os.NewFile(uintptr(fd), name)
+	}
+	return files, nil
+}
+
+// Addr returns the address ln listens on in the form Listen accepts,
+// host:port or unix:///path.
+func Addr(ln net.Listener) string {
+	if a, ok := ln.Addr().(*net.UnixAddr); ok {
+		return UnixScheme + a.Name
+	}
+	return ln.Addr().String()
+}
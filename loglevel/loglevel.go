@@ -0,0 +1,73 @@
+// Package loglevel filters log records below a minimum level that can be
+// changed while the service runs. Records are leveled with go-kit's
+// log/level package; records without a level count as info.
+package loglevel
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// Names of the levels, from the most to the least verbose.
+const (
+	Debug = "debug"
+	Info  = "info"
+	Warn  = "warn"
+	Error = "error"
+)
+
+var ranks = map[string]int32{Debug: 0, Info: 1, Warn: 2, Error: 3}
+
+// Validate returns an error if name is not one of the level names.
+func Validate(name string) error {
+	if _, ok := ranks[name]; !ok {
+		return fmt.Errorf("unknown level %q, want one of debug, info, warn or error", name)
+	}
+	return nil
+}
+
+// Filter is a log.Logger passing records at or above its level on
+// to the next logger. It is safe for concurrent use.
+type Filter struct {
+	next log.Logger
+	min  int32
+}
+
+// NewFilter returns a Filter of next at the named level.
+func NewFilter(next log.Logger, name string) (*Filter, error) {
+	f := &Filter{next: next}
+	if err := f.SetLevel(name); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SetLevel changes the minimum level of records passed on.
+func (f *Filter) SetLevel(name string) error {
+	if err := Validate(name); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&f.min, ranks[name])
+	return nil
+}
+
+// Log implements log.Logger.
+func (f *Filter) Log(keyvals ...interface{}) error {
+	rank := ranks[Info]
+	for i := 0; i < len(keyvals)-1; i += 2 {
+		if keyvals[i] != level.Key() {
+			continue
+		}
+		if v, ok := keyvals[i+1].(level.Value); ok {
+			rank = ranks[v.String()]
+		}
+		break
+	}
+	if rank < atomic.LoadInt32(&f.min) {
+		return nil
+	}
+	return f.next.Log(keyvals...)
+}
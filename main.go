@@ -1,94 +1,67 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"net"
-	"net/http"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"flag"
-
-	"github.com/afrometal/go-kit-svc/stringsvc"
-	"github.com/afrometal/go-kit-svc/stringsvc/proto"
-	"github.com/go-kit/kit/log"
-	"google.golang.org/grpc"
+	"github.com/afrometal/go-kit-svc/config"
+	"github.com/afrometal/go-kit-svc/server"
 )
 
 func main() {
-	var (
-		httpAddr = flag.String("http-addr", ":8080",
-			"HTTP address")
-		grpcAddr = flag.String("grpc-addr", ":8081",
-			"gRPC address")
-	)
-	flag.Parse()
+	cfg, err := loadConfig(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		if err == config.ErrPrinted {
+			return
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	// Logging domain.
-	var logger log.Logger
-	{
-		logger = log.NewLogfmtLogger(os.Stdout)
-		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
-		logger = log.With(logger, "caller", log.DefaultCaller)
+	srv, err := server.New(cfg, server.Options{
+		// Runtime settings, reloaded on SIGHUP and POST /reload.
+		Reload: func() (*server.Config, error) {
+			fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			return loadConfig(fs, os.Args[1:])
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
+	logger := srv.Logger()
 	logger.Log("msg", "hello")
 	defer logger.Log("msg", "goodbye")
 
-	// Business domain.
-	var svc stringsvc.StringService
-	{
-		svc = stringsvc.New()
-		svc = stringsvc.NewLoggingMiddleware(svc, logger)
-		svc = stringsvc.NewInstrumentingMiddleware(svc)
+	if _, err := srv.Start(context.Background()); err != nil {
+		logger.Log("during", "start", "err", err)
+		os.Exit(1)
 	}
 
-	// Endpoint domain.
-	var endpoints stringsvc.Endpoints
-	{
-		endpoints = stringsvc.Endpoints{
-			TitleCaseEndpoint:        stringsvc.MakeTitleCaseEndpoint(svc),
-			RemoveWhitespaceEndpoint: stringsvc.MakeRemoveWhitespaceEndpoint(svc),
-			CountEndpoint:            stringsvc.MakeCountEndpoint(svc),
-		}
+	// Run until interrupted or serving fails.
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-c:
+		logger.Log("exit", sig)
+	case err := <-srv.Err():
+		logger.Log("exit", err)
 	}
 
-	// Error channel.
-	errc := make(chan error)
-
-	// Interrupt handler.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	// HTTP transport.
-	go func() {
-		logger := log.With(logger, "transport", "HTTP")
-		logger.Log("addr", *httpAddr)
-
-		handler := stringsvc.MakeHTTPHandler(endpoints, logger)
-		errc <- http.ListenAndServe(*httpAddr, handler)
-	}()
-
-	// gRPC transport.
-	go func() {
-		logger := log.With(logger, "transport", "gRPC")
-		logger.Log("addr", *grpcAddr)
-
-		ln, err := net.Listen("tcp", *grpcAddr)
-		if err != nil {
-			errc <- err
-			return
-		}
-
-		srv := stringsvc.MakeGRPCServer(endpoints, logger)
-		s := grpc.NewServer()
-		proto.RegisterStringServer(s, srv)
-		errc <- s.Serve(ln)
-	}()
+	srv.Stop(context.Background())
+}
 
-	// Run!
-	logger.Log("exit", <-errc)
+// loadConfig loads the configuration from the file given with -config or
+// STRINGSVC_CONFIG, STRINGSVC_* environment variables and the flags in
+// args, defined in fs.
+func loadConfig(fs *flag.FlagSet, args []string) (*server.Config, error) {
+	cfg := server.DefaultConfig()
+	loader := config.Loader{EnvPrefix: "STRINGSVC", FlagSet: fs}
+	return cfg, loader.Load(cfg, args)
 }
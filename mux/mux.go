@@ -0,0 +1,43 @@
+// Package mux serves gRPC and the JSON HTTP API on a single listener.
+// Requests are told apart by protocol and content type: gRPC calls are
+// HTTP/2 requests of an application/grpc content type, everything else
+// is handed to the HTTP handler.
+package mux
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// Configure makes srv serve calls of grpcServer and requests of
+// httpHandler. HTTP/2 is negotiated with ALPN over TLS and accepted in
+// cleartext (h2c) otherwise, as gRPC clients dialing without transport
+// security expect. On srv.Shutdown, HTTP/2 connections are drained
+// gracefully.
+func Configure(srv *http.Server, grpcServer, httpHandler http.Handler) error {
+	h2s := &http2.Server{}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return err
+	}
+	srv.Handler = h2c.NewHandler(Handler(grpcServer, httpHandler), h2s)
+	return nil
+}
+
+// Handler routes gRPC calls to grpcServer and the other requests to httpHandler.
+func Handler(grpcServer, httpHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsGRPC(r) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+}
+
+// IsGRPC reports whether r is a gRPC call.
+func IsGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
@@ -0,0 +1,82 @@
+// Package reload re-applies the runtime settings of the service on SIGHUP
+// or on request of an admin endpoint, without restarting it.
+package reload
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// Func loads and applies the settings. It must leave the current settings
+// in place when it returns an error.
+type Func func() error
+
+// Reloader serializes reloads triggered by signals and HTTP requests.
+type Reloader struct {
+	fn     Func
+	logger log.Logger
+
+	mtx sync.Mutex
+}
+
+// New returns a Reloader calling fn and logging the outcome to logger.
+func New(fn Func, logger log.Logger) *Reloader {
+	return &Reloader{fn: fn, logger: logger}
+}
+
+// Reload calls the Func unless another reload is in progress,
+// in which case it waits for it first.
+func (r *Reloader) Reload(trigger string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if err := r.fn(); err != nil {
+		level.Error(r.logger).Log("during", "reload", "trigger", trigger, "err", err)
+		return err
+	}
+	r.logger.Log("during", "reload", "trigger", trigger, "msg", "settings applied")
+	return nil
+}
+
+// Run reloads on every SIGHUP until ctx is done.
+func (r *Reloader) Run(ctx context.Context) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	defer signal.Stop(c)
+	for {
+		select {
+		case <-c:
+			r.Reload("SIGHUP")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ServeHTTP reloads on POST requests, answering with status 422
+// and the error if the settings were rejected.
+func (r *Reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	result := struct {
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}{Status: "applied"}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := r.Reload("admin"); err != nil {
+		result.Status, result.Error = "rejected", err.Error()
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	json.NewEncoder(w).Encode(result)
+}
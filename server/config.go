@@ -0,0 +1,229 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/capture"
+	"github.com/afrometal/go-kit-svc/config"
+	"github.com/afrometal/go-kit-svc/discovery"
+	"github.com/afrometal/go-kit-svc/feature"
+	"github.com/afrometal/go-kit-svc/health"
+	"github.com/afrometal/go-kit-svc/listen"
+	"github.com/afrometal/go-kit-svc/loglevel"
+	"github.com/afrometal/go-kit-svc/slo"
+	"github.com/afrometal/go-kit-svc/stringsvc"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/sd"
+)
+
+// Config is the configuration of the service. Its yaml tags and usage
+// let package config load it from a file, environment variables and flags.
+// The log level, methods, flags, tenancy, capture sampling and redaction
+// and health settings can be changed while the server runs, see Server.Apply.
+type Config struct {
+	HTTPAddr  string `yaml:"http_addr" usage:"HTTP address: host:port, unix:///path or systemd:name"`
+	GRPCAddr  string `yaml:"grpc_addr" usage:"gRPC address: host:port, unix:///path or systemd:name"`
+	AdminAddr string `yaml:"admin_addr" usage:"HTTP address of the admin endpoints"`
+	Addr      string `yaml:"addr" usage:"address serving both HTTP and gRPC, replaces http_addr and grpc_addr if set"`
+
+	Unix struct {
+		Mode listen.FileMode `yaml:"mode" usage:"permissions of Unix socket files, such as 0660"`
+	} `yaml:"unix"`
+
+	TLS struct {
+		CertFile string `yaml:"cert_file" usage:"TLS certificate file, serves HTTP and gRPC over TLS if set"`
+		KeyFile  string `yaml:"key_file" usage:"TLS private key file"`
+	} `yaml:"tls"`
+
+	Discovery struct {
+		Dir           string            `yaml:"dir" usage:"registry directory to register the instance in, registration is disabled if empty"`
+		TTL           time.Duration     `yaml:"ttl" usage:"duration a registration lasts unless renewed, it is renewed every half of it"`
+		ID            string            `yaml:"id" usage:"instance ID, host name and process ID if empty"`
+		AdvertiseHTTP string            `yaml:"advertise_http" usage:"HTTP address registered, derived from the listener if empty"`
+		AdvertiseGRPC string            `yaml:"advertise_grpc" usage:"gRPC address registered, derived from the listener if empty"`
+		Metadata      map[string]string `yaml:"metadata"`
+	} `yaml:"discovery"`
+
+	Log struct {
+		Level string `yaml:"level" usage:"minimum level of logged records: debug, info, warn or error"`
+	} `yaml:"log"`
+
+	Methods stringsvc.PolicySettings `yaml:"methods"`
+
+	Flags map[string]feature.Flag `yaml:"flags"`
+
+	Tenancy stringsvc.TenancySettings `yaml:"tenancy"`
+
+	Regex stringsvc.RegexLimits `yaml:"regex"`
+
+	SLO slo.Config `yaml:"slo"`
+
+	Audit struct {
+		File       string        `yaml:"file" usage:"audit log file, auditing is disabled if empty"`
+		MaxSize    int64         `yaml:"max_size" usage:"size in bytes after which the audit log is rotated"`
+		MaxAge     time.Duration `yaml:"max_age" usage:"age after which the audit log is rotated"`
+		MaxBackups int           `yaml:"max_backups" usage:"number of rotated audit logs to keep, all if 0"`
+	} `yaml:"audit"`
+
+	Capture struct {
+		File   string   `yaml:"file" usage:"file to capture requests and responses to, capturing is disabled if empty"`
+		Sample float64  `yaml:"sample" usage:"ratio of requests captured, from 0 to 1"`
+		Redact []string `yaml:"redact" secret:"true" usage:"regular expression replaced in captured strings, may be repeated"`
+	} `yaml:"capture"`
+
+	Inspect struct {
+		Size int           `yaml:"size" usage:"number of calls kept in each /debug/requests bucket of a method"`
+		Slow time.Duration `yaml:"slow" usage:"duration above which calls are kept in the slow /debug/requests bucket"`
+	} `yaml:"inspect"`
+
+	Health struct {
+		TTL           time.Duration `yaml:"ttl" usage:"duration health check results are cached for"`
+		WatchInterval time.Duration `yaml:"watch_interval" usage:"interval gRPC health Watch streams re-evaluate the readiness at"`
+	} `yaml:"health"`
+
+	Shutdown struct {
+		Delay        time.Duration `yaml:"delay" usage:"duration between failing readiness and draining connections on shutdown"`
+		DrainTimeout time.Duration `yaml:"drain_timeout" flag:"drain-timeout" usage:"duration in-flight requests are given to finish on shutdown"`
+	} `yaml:"shutdown"`
+}
+
+// DefaultConfig returns the configuration used for settings left unset.
+func DefaultConfig() *Config {
+	c := &Config{
+		HTTPAddr:  ":8080",
+		GRPCAddr:  ":8081",
+		AdminAddr: ":8082",
+	}
+	c.Discovery.TTL = 30 * time.Second
+	c.Log.Level = loglevel.Info
+	c.Regex = stringsvc.DefaultRegexLimits()
+	c.Audit.MaxSize = 100 << 20
+	c.Audit.MaxAge = 24 * time.Hour
+	c.Capture.Sample = 1
+	c.Inspect.Size = 200
+	c.Inspect.Slow = 100 * time.Millisecond
+	c.Health.TTL = 2 * time.Second
+	c.Health.WatchInterval = time.Second
+	c.Shutdown.Delay = 5 * time.Second
+	c.Shutdown.DrainTimeout = 20 * time.Second
+	return c
+}
+
+// Validate implements config.Validator.
+func (c *Config) Validate() error {
+	var errs config.Errors
+	errs.Check(c.HTTPAddr != "", "http_addr", "must not be empty")
+	errs.Check(c.GRPCAddr != "", "grpc_addr", "must not be empty")
+	errs.Check(c.AdminAddr != "", "admin_addr", "must not be empty")
+	errs.Check(c.TLS.CertFile == "" || c.TLS.KeyFile != "", "tls.key_file", "is required with tls.cert_file")
+	errs.Check(c.TLS.KeyFile == "" || c.TLS.CertFile != "", "tls.cert_file", "is required with tls.key_file")
+	errs.Check(c.Discovery.TTL >= 2*time.Second, "discovery.ttl", "must be at least 2s, got %s", c.Discovery.TTL)
+	if err := loglevel.Validate(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %v", err))
+	}
+	if err := c.Methods.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("methods.%v", err))
+	}
+	for m, f := range c.Flags {
+		errs.Check(stringsvc.IsMethod(m), "flags", "unknown method %q", m)
+		if err := f.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("flags.%s: %v", m, err))
+		}
+	}
+	if err := c.Tenancy.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("tenancy.%v", err))
+	}
+	if err := c.Regex.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("regex.%v", err))
+	}
+	if err := c.SLO.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("slo: %v", err))
+	}
+	for i, o := range c.SLO.Objectives {
+		errs.Check(o.Method == "" || stringsvc.IsMethod(o.Method), "slo.objectives", "objective %d: unknown method %q", i, o.Method)
+	}
+	errs.Check(c.Audit.MaxSize >= 0, "audit.max_size", "must not be negative, got %d", c.Audit.MaxSize)
+	errs.Check(c.Audit.MaxAge >= 0, "audit.max_age", "must not be negative, got %s", c.Audit.MaxAge)
+	errs.Check(c.Audit.MaxBackups >= 0, "audit.max_backups", "must not be negative, got %d", c.Audit.MaxBackups)
+	errs.Check(c.Capture.Sample >= 0 && c.Capture.Sample <= 1, "capture.sample", "must be between 0 and 1, got %v", c.Capture.Sample)
+	for i, expr := range c.Capture.Redact {
+		_, err := regexp.Compile(expr)
+		errs.Check(err == nil, "capture.redact", "pattern %d: %v", i, err)
+	}
+	errs.Check(c.Inspect.Size > 0, "inspect.size", "must be positive, got %d", c.Inspect.Size)
+	errs.Check(c.Inspect.Slow >= 0, "inspect.slow", "must not be negative, got %s", c.Inspect.Slow)
+	errs.Check(c.Health.TTL >= 0, "health.ttl", "must not be negative, got %s", c.Health.TTL)
+	errs.Check(c.Health.WatchInterval >= health.MinWatchInterval, "health.watch_interval", "must be at least %s, got %s", health.MinWatchInterval, c.Health.WatchInterval)
+	errs.Check(c.Shutdown.Delay >= 0, "shutdown.delay", "must not be negative, got %s", c.Shutdown.Delay)
+	errs.Check(c.Shutdown.DrainTimeout > 0, "shutdown.drain_timeout", "must be positive, got %s", c.Shutdown.DrainTimeout)
+	return errs.Err()
+}
+
+// static returns the settings that are only applied at startup.
+func (c *Config) static() Config {
+	s := *c
+	s.Log.Level = ""
+	s.Methods = stringsvc.PolicySettings{}
+	s.Flags = nil
+	s.Tenancy = stringsvc.TenancySettings{}
+	s.Capture.Sample, s.Capture.Redact = 0, nil
+	s.Health.TTL = 0
+	return s
+}
+
+// registrars returns the registrars of the bound HTTP and gRPC addresses.
+func (c *Config) registrars(addrs Addrs, logger log.Logger) ([]sd.Registrar, error) {
+	id := c.Discovery.ID
+	if id == "" {
+		id = discovery.DefaultID()
+	}
+	httpAddr, grpcAddr := addrs.HTTP, addrs.GRPC
+	backend := discovery.Dir{Path: c.Discovery.Dir}
+	var registrars []sd.Registrar
+	for _, t := range []struct {
+		service, transport, addr, advertise string
+	}{
+		{discovery.ServiceHTTP, "http", httpAddr, c.Discovery.AdvertiseHTTP},
+		{discovery.ServiceGRPC, "grpc", grpcAddr, c.Discovery.AdvertiseGRPC},
+	} {
+		addr := t.advertise
+		if addr == "" {
+			var err error
+			if addr, err = discovery.Advertise(t.addr); err != nil {
+				return nil, fmt.Errorf("%s: %v", t.service, err)
+			}
+		}
+		inst := discovery.Instance{
+			ID:      id,
+			Service: t.service,
+			Address: addr,
+			Metadata: map[string]string{
+				"transport": t.transport,
+				"tls":       fmt.Sprint(c.TLS.CertFile != ""),
+			},
+		}
+		for k, v := range c.Discovery.Metadata {
+			inst.Metadata[k] = v
+		}
+		registrars = append(registrars, discovery.NewRegistrar(backend, inst, c.Discovery.TTL, logger))
+	}
+	return registrars, nil
+}
+
+// listenOptions returns the options of the listeners.
+func (c *Config) listenOptions() listen.Options {
+	return listen.Options{Mode: os.FileMode(c.Unix.Mode)}
+}
+
+// captureOptions returns the recorder options with the validated
+// redaction patterns compiled.
+func (c *Config) captureOptions() capture.Options {
+	opts := capture.Options{SampleRate: c.Capture.Sample}
+	for _, expr := range c.Capture.Redact {
+		opts.Redact = append(opts.Redact, regexp.MustCompile(expr))
+	}
+	return opts
+}
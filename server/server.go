@@ -0,0 +1,498 @@
+// Package server assembles the string service: the middleware stack, the
+// HTTP and gRPC transports, the admin endpoints and the registration in
+// service discovery. The service binary is a thin wrapper around it, and
+// tests embed it to run the service in process.
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/audit"
+	"github.com/afrometal/go-kit-svc/capture"
+	"github.com/afrometal/go-kit-svc/feature"
+	"github.com/afrometal/go-kit-svc/health"
+	"github.com/afrometal/go-kit-svc/inspector"
+	"github.com/afrometal/go-kit-svc/listen"
+	"github.com/afrometal/go-kit-svc/loglevel"
+	"github.com/afrometal/go-kit-svc/mux"
+	"github.com/afrometal/go-kit-svc/reload"
+	"github.com/afrometal/go-kit-svc/slo"
+	"github.com/afrometal/go-kit-svc/stringsvc"
+	"github.com/afrometal/go-kit-svc/stringsvc/proto"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-kit/kit/sd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Options of a Server besides its configuration. The zero value runs the
+// service as the binary does.
+type Options struct {
+	// Logger receives the records of the server, filtered by the
+	// configured level. Records are written as logfmt to stdout if nil.
+	Logger log.Logger
+
+	// Registerer registers the metrics of the server and Gatherer gathers
+	// the metrics served on /metrics. The default Prometheus registry is
+	// used if nil, which takes the metrics of a single Server per process.
+	Registerer prometheus.Registerer
+	Gatherer   prometheus.Gatherer
+
+	// Reload returns the configuration applied on SIGHUP and POST /reload
+	// to the admin listener. Reloading is disabled if nil.
+	Reload func() (*Config, error)
+}
+
+// Addrs are the addresses a Server listens on, in the form of the
+// configured ones with the ports chosen by the system for port 0.
+// HTTP and GRPC are the same address in single port mode.
+type Addrs struct {
+	HTTP  string
+	GRPC  string
+	Admin string
+}
+
+// Server is the string service with its transports and admin endpoints.
+type Server struct {
+	cfg    *Config
+	logger log.Logger
+
+	levels   *loglevel.Filter
+	checks   *health.Registry
+	policy   *stringsvc.Policy
+	flags    *feature.Flags
+	tenancy  *stringsvc.Tenancy
+	recorder *capture.Recorder
+	reloader *reload.Reloader
+	closers  []io.Closer
+
+	grpcServer  *grpc.Server
+	grpcCalls   *callTracker // gRPC calls in flight in single port mode
+	httpServer  *http.Server
+	adminServer *http.Server
+
+	registrars []sd.Registrar
+	cancel     context.CancelFunc
+	errc       chan error
+}
+
+// New returns a Server of the validated configuration, ready to Start.
+func New(cfg *Config, opts Options) (*Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.NewLogfmtLogger(os.Stdout)
+	}
+	if opts.Registerer == nil {
+		opts.Registerer = prometheus.DefaultRegisterer
+	}
+	if opts.Gatherer == nil {
+		opts.Gatherer = prometheus.DefaultGatherer
+	}
+	s := &Server{cfg: cfg, cancel: func() {}, errc: make(chan error, 1)}
+
+	// Logging domain.
+	var err error
+	if s.levels, err = loglevel.NewFilter(opts.Logger, cfg.Log.Level); err != nil {
+		return nil, err
+	}
+	{
+		s.logger = s.levels
+		s.logger = log.With(s.logger, "ts", log.DefaultTimestampUTC)
+		s.logger = log.With(s.logger, "caller", log.DefaultCaller)
+	}
+	logger := s.logger
+
+	var grpcOptions []grpc.ServerOption
+	if cfg.TLS.CertFile != "" && cfg.Addr == "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		grpcOptions = append(grpcOptions, grpc.Creds(creds))
+	}
+
+	// Service level objectives.
+	tracker := slo.NewTracker(cfg.SLO, opts.Registerer)
+
+	// Business domain.
+	var svc stringsvc.StringService
+	{
+		svc = stringsvc.NewWithRegexLimits(cfg.Regex)
+		svc = stringsvc.NewLoggingMiddleware(svc, logger)
+		svc = stringsvc.NewInstrumentingMiddleware(svc, opts.Registerer, tracker)
+	}
+
+	// Health checks.
+	s.checks = health.NewRegistry(cfg.Health.TTL)
+
+	// Endpoint domain.
+	requests := inspector.New(inspector.Options{Size: cfg.Inspect.Size, Slow: cfg.Inspect.Slow})
+	if s.policy, err = stringsvc.NewPolicy(cfg.Methods); err != nil {
+		return nil, err
+	}
+	if s.flags, err = feature.New(cfg.Flags); err != nil {
+		return nil, err
+	}
+	if s.tenancy, err = stringsvc.NewTenancy(cfg.Tenancy); err != nil {
+		return nil, err
+	}
+	// Audit comes first so that calls rejected by the other middlewares
+	// are recorded too.
+	var mws []stringsvc.MethodMiddleware
+	if cfg.Audit.File != "" {
+		f := &audit.RotatingFile{
+			Path:       cfg.Audit.File,
+			MaxSize:    cfg.Audit.MaxSize,
+			MaxAge:     cfg.Audit.MaxAge,
+			MaxBackups: cfg.Audit.MaxBackups,
+		}
+		s.closers = append(s.closers, f)
+		s.checks.Register("audit", f)
+		mws = append(mws, stringsvc.NewAuditMiddleware(audit.NewLogger(f), logger))
+	}
+	mws = append(mws,
+		stringsvc.NewInspectorMiddleware(requests),
+		stringsvc.NewVersionMiddleware(),
+		stringsvc.NewTenantMiddleware(s.tenancy),
+		stringsvc.NewFeatureMiddleware(s.flags),
+		stringsvc.NewPolicyMiddleware(s.policy),
+	)
+	if cfg.Capture.File != "" {
+		f, err := os.OpenFile(cfg.Capture.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.closers = append(s.closers, f)
+		s.recorder = capture.NewRecorder(f, cfg.captureOptions())
+		s.checks.Register("capture", s.recorder, health.NonCritical())
+		mws = append(mws, stringsvc.NewCaptureMiddleware(s.recorder, logger))
+	}
+	endpoints := stringsvc.NewEndpoints(svc, mws...)
+
+	// Runtime settings, reloaded on SIGHUP and POST /reload.
+	if opts.Reload != nil {
+		s.reloader = reload.New(func() error {
+			next, err := opts.Reload()
+			if err != nil {
+				return err
+			}
+			return s.Apply(next)
+		}, logger)
+	}
+
+	// gRPC transport.
+	s.grpcServer = grpc.NewServer(grpcOptions...)
+	{
+		srv := stringsvc.MakeGRPCServer(endpoints, log.With(logger, "transport", "gRPC"))
+		proto.RegisterStringServer(s.grpcServer, srv)
+		healthpb.RegisterHealthServer(s.grpcServer, health.NewGRPCServer(s.checks, cfg.Health.WatchInterval, "proto.String"))
+	}
+
+	// HTTP transport.
+	{
+		m := http.NewServeMux()
+		m.Handle("/", stringsvc.MakeHTTPHandler(endpoints, log.With(logger, "transport", "HTTP")))
+		m.Handle("/metrics", promhttp.InstrumentMetricHandler(opts.Registerer,
+			promhttp.HandlerFor(opts.Gatherer, promhttp.HandlerOpts{})))
+		s.httpServer = &http.Server{Handler: m}
+	}
+	if cfg.Addr != "" {
+		// Both transports on a single listener.
+		s.grpcCalls = newCallTracker()
+		if err := mux.Configure(s.httpServer, s.grpcCalls.Handler(s.grpcServer), s.httpServer.Handler); err != nil {
+			s.close()
+			return nil, err
+		}
+	}
+
+	// Admin endpoints.
+	{
+		m := http.NewServeMux()
+		m.Handle("/slo", tracker)
+		m.Handle("/debug/requests", requests)
+		m.Handle("/healthz", s.checks.LivenessHandler())
+		m.Handle("/readyz", s.checks.ReadinessHandler())
+		if s.reloader != nil {
+			m.Handle("/reload", s.reloader)
+		}
+		m.Handle("/flags", s.flags.Handler(stringsvc.Methods))
+		s.adminServer = &http.Server{Handler: m}
+	}
+	return s, nil
+}
+
+// Logger returns the logger of the server, filtered by the configured level.
+func (s *Server) Logger() log.Logger {
+	return s.logger
+}
+
+// Start listens on the configured addresses and serves them until Stop.
+// It returns once all listeners are bound, and registers the instance if
+// discovery is configured. Reloading on SIGHUP stops when ctx is done.
+func (s *Server) Start(ctx context.Context) (Addrs, error) {
+	var (
+		addrs Addrs
+		lns   []net.Listener
+	)
+	bind := func(addr string) (net.Listener, error) {
+		ln, err := listen.Listen(addr, s.cfg.listenOptions())
+		if err != nil {
+			for _, ln := range lns {
+				ln.Close()
+			}
+			return nil, err
+		}
+		lns = append(lns, ln)
+		return ln, nil
+	}
+
+	var httpLn, grpcLn net.Listener
+	var err error
+	if s.cfg.Addr != "" {
+		if httpLn, err = bind(s.cfg.Addr); err != nil {
+			return Addrs{}, err
+		}
+		addrs.HTTP = listen.Addr(httpLn)
+		addrs.GRPC = addrs.HTTP
+	} else {
+		if httpLn, err = bind(s.cfg.HTTPAddr); err != nil {
+			return Addrs{}, err
+		}
+		if grpcLn, err = bind(s.cfg.GRPCAddr); err != nil {
+			return Addrs{}, err
+		}
+		addrs.HTTP, addrs.GRPC = listen.Addr(httpLn), listen.Addr(grpcLn)
+	}
+	adminLn, err := bind(s.cfg.AdminAddr)
+	if err != nil {
+		return Addrs{}, err
+	}
+	addrs.Admin = listen.Addr(adminLn)
+
+	if s.cfg.Discovery.Dir != "" {
+		if s.registrars, err = s.cfg.registrars(addrs, s.logger); err != nil {
+			for _, ln := range lns {
+				ln.Close()
+			}
+			return Addrs{}, err
+		}
+	}
+
+	tls := s.cfg.TLS.CertFile != ""
+	if grpcLn == nil {
+		log.With(s.logger, "transport", "HTTP+gRPC").Log("addr", addrs.HTTP, "tls", tls)
+	} else {
+		log.With(s.logger, "transport", "HTTP").Log("addr", addrs.HTTP, "tls", tls)
+		log.With(s.logger, "transport", "gRPC").Log("addr", addrs.GRPC, "tls", tls)
+		go func() {
+			s.fail(s.grpcServer.Serve(grpcLn))
+		}()
+	}
+	go func() {
+		var err error
+		if tls {
+			err = s.httpServer.ServeTLS(httpLn, s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+		} else {
+			err = s.httpServer.Serve(httpLn)
+		}
+		if err != http.ErrServerClosed {
+			s.fail(err)
+		}
+	}()
+	log.With(s.logger, "transport", "admin").Log("addr", addrs.Admin)
+	go func() {
+		if err := s.adminServer.Serve(adminLn); err != http.ErrServerClosed {
+			s.fail(err)
+		}
+	}()
+
+	// Service registration.
+	for _, r := range s.registrars {
+		r.Register()
+	}
+
+	if s.reloader != nil {
+		ctx, s.cancel = context.WithCancel(ctx)
+		go s.reloader.Run(ctx)
+	}
+	return addrs, nil
+}
+
+// Err returns a channel receiving the error serving failed with, if any.
+func (s *Server) Err() <-chan error {
+	return s.errc
+}
+
+func (s *Server) fail(err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case s.errc <- err:
+	default:
+	}
+}
+
+// Stop shuts the server down gracefully. It deregisters the instance and
+// fails readiness, waits for the configured delay and then gives in-flight
+// calls the drain timeout to finish, within ctx. Calls still running are
+// aborted and their error is returned.
+func (s *Server) Stop(ctx context.Context) error {
+	logger := log.With(s.logger, "during", "shutdown")
+	logger.Log("phase", "unready")
+	s.cancel()
+	for _, r := range s.registrars {
+		r.Deregister()
+	}
+	s.checks.Register("shutdown", health.CheckerFunc(func(context.Context) error {
+		return errShuttingDown
+	}))
+	logger.Log("phase", "delay", "duration", s.cfg.Shutdown.Delay)
+	select {
+	case <-time.After(s.cfg.Shutdown.Delay):
+	case <-ctx.Done():
+	}
+
+	logger.Log("phase", "drain", "timeout", s.cfg.Shutdown.DrainTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Shutdown.DrainTimeout)
+	defer cancel()
+	var (
+		wg     sync.WaitGroup
+		forced = make(chan error, 2)
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			logger.Log("phase", "force", "transport", "HTTP", "err", err)
+			s.httpServer.Close()
+			forced <- err
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		stopped := make(chan struct{})
+		go func() {
+			if s.grpcCalls != nil {
+				// Served through ServeHTTP, gRPC cannot drain itself and
+				// GracefulStop panics while streams are open. The HTTP
+				// server sends HTTP/2 connections GOAWAY instead, and the
+				// calls in flight are waited for.
+				s.grpcCalls.Wait()
+				s.grpcServer.Stop()
+			} else {
+				s.grpcServer.GracefulStop()
+			}
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			logger.Log("phase", "force", "transport", "gRPC", "err", ctx.Err())
+			s.grpcServer.Stop()
+			forced <- ctx.Err()
+		}
+	}()
+	wg.Wait()
+
+	logger.Log("phase", "admin")
+	if err := s.adminServer.Shutdown(ctx); err != nil {
+		s.adminServer.Close()
+	}
+	s.close()
+	logger.Log("phase", "done")
+
+	select {
+	case err := <-forced:
+		return err
+	default:
+		return nil
+	}
+}
+
+// close closes the files the server writes to.
+func (s *Server) close() {
+	for _, c := range s.closers {
+		c.Close()
+	}
+}
+
+// Apply applies the settings of cfg that can change while the server
+// runs, see Config. Other settings are ignored with a warning.
+func (s *Server) Apply(cfg *Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(cfg.static(), s.cfg.static()) {
+		level.Warn(s.logger).Log("during", "reload", "msg", "only log, methods, flags, tenancy, capture sampling and redaction and health settings are reloaded, others require a restart")
+	}
+	s.levels.SetLevel(cfg.Log.Level)
+	s.policy.Update(cfg.Methods)
+	s.flags.Set(cfg.Flags)
+	s.tenancy.Update(cfg.Tenancy)
+	if s.recorder != nil {
+		s.recorder.SetOptions(cfg.captureOptions())
+	}
+	s.checks.SetTTL(cfg.Health.TTL)
+	return nil
+}
+
+// callTracker counts the calls in flight through a handler.
+type callTracker struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{} // closed while no call is in flight
+}
+
+func newCallTracker() *callTracker {
+	idle := make(chan struct{})
+	close(idle)
+	return &callTracker{idle: idle}
+}
+
+// Handler returns h counting its calls.
+func (t *callTracker) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.add(1)
+		defer t.add(-1)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (t *callTracker) add(delta int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.n == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.n += delta
+	if t.n == 0 {
+		close(t.idle)
+	}
+}
+
+// Wait returns once no call is in flight.
+func (t *callTracker) Wait() {
+	t.mu.Lock()
+	idle := t.idle
+	t.mu.Unlock()
+	<-idle
+}
+
+// errShuttingDown fails readiness once shutdown has begun.
+var errShuttingDown = errors.New("shutting down")
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestStopWithStreamInFlight(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		singlePort bool
+	}{
+		{"separate ports", false},
+		{"single port", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.HTTPAddr, cfg.GRPCAddr, cfg.AdminAddr = "127.0.0.1:0", "127.0.0.1:0", "127.0.0.1:0"
+			if tc.singlePort {
+				cfg.Addr = "127.0.0.1:0"
+			}
+			cfg.Shutdown.Delay = 0
+			cfg.Shutdown.DrainTimeout = 200 * time.Millisecond
+			registry := prometheus.NewRegistry()
+			s, err := New(cfg, Options{Logger: log.NewNopLogger(), Registerer: registry, Gatherer: registry})
+			if err != nil {
+				t.Fatal(err)
+			}
+			addrs, err := s.Start(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			conn, err := grpc.Dial(addrs.GRPC, grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				t.Fatal(err)
+			}
+
+			stopped := make(chan error, 1)
+			go func() { stopped <- s.Stop(context.Background()) }()
+			select {
+			case err := <-stopped:
+				// The stream never ends by itself and is aborted.
+				if err != context.DeadlineExceeded {
+					t.Errorf("Stop returned %v, want %v", err, context.DeadlineExceeded)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Stop did not return")
+			}
+			for {
+				if _, err := stream.Recv(); err != nil {
+					break
+				}
+			}
+		})
+	}
+}
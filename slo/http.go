@@ -0,0 +1,14 @@
+package slo
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ServeHTTP writes the JSON-encoded Report of the tracker.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(t.Report())
+}
@@ -0,0 +1,52 @@
+package slo
+
+import "time"
+
+// ring is a fixed number of consecutive time buckets counting good
+// and total requests. Buckets are reused once they fall out of range.
+type ring struct {
+	width   time.Duration
+	buckets []bucket
+}
+
+type bucket struct {
+	slot  int64
+	good  float64
+	total float64
+}
+
+func newRing(width time.Duration, n int) *ring {
+	if n < 1 {
+		n = 1
+	}
+	return &ring{width: width, buckets: make([]bucket, n)}
+}
+
+func (r *ring) slot(t time.Time) int64 {
+	return t.UnixNano() / int64(r.width)
+}
+
+func (r *ring) add(t time.Time, good bool) {
+	slot := r.slot(t)
+	b := &r.buckets[slot%int64(len(r.buckets))]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	b.total++
+	if good {
+		b.good++
+	}
+}
+
+// sum returns the counts of the buckets that overlap span before t.
+func (r *ring) sum(t time.Time, span time.Duration) (good, total float64) {
+	last := r.slot(t)
+	first := last - int64((span+r.width-1)/r.width) + 1
+	for _, b := range r.buckets {
+		if b.slot >= first && b.slot <= last {
+			good += b.good
+			total += b.total
+		}
+	}
+	return good, total
+}
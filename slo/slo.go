@@ -0,0 +1,100 @@
+// Package slo tracks service level objectives of the string service methods
+// and reports the remaining error budget together with its burn rates.
+package slo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultWindow is the compliance period used when none is configured.
+const DefaultWindow = 30 * 24 * time.Hour
+
+// Objective declares the service level objective of a single method.
+// A request is good when it succeeds within the Latency threshold,
+// and Target is the ratio of good requests that is promised.
+type Objective struct {
+	Method  string   `json:"method" yaml:"method"`
+	Target  float64  `json:"target" yaml:"target"`
+	Latency Duration `json:"latency,omitempty" yaml:"latency,omitempty"`
+}
+
+// Config is a set of objectives evaluated over a common compliance window.
+type Config struct {
+	Window     Duration    `json:"window,omitempty" yaml:"window,omitempty" usage:"compliance window of the objectives, 30 days if 0"`
+	Objectives []Objective `json:"objectives" yaml:"objectives"`
+}
+
+// Duration is a time.Duration that (un)marshals as a string
+// such as "300ms" or "720h".
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Validate checks that every objective is well-formed and declared once.
+func (c Config) Validate() error {
+	if c.Window < 0 {
+		return errors.New("window must not be negative")
+	}
+	seen := map[string]bool{}
+	for i, o := range c.Objectives {
+		switch {
+		case o.Method == "":
+			return fmt.Errorf("objectives[%d]: method is required", i)
+		case seen[o.Method]:
+			return fmt.Errorf("objectives[%d]: duplicate objective for %q", i, o.Method)
+		case o.Target <= 0 || o.Target >= 1:
+			return fmt.Errorf("objectives[%d]: target must be between 0 and 1, got %v", i, o.Target)
+		case o.Latency < 0:
+			return fmt.Errorf("objectives[%d]: latency must not be negative", i)
+		}
+		seen[o.Method] = true
+	}
+	return nil
+}
+
+// good reports whether a request meets the objective.
+func (o Objective) good(failed bool, took time.Duration) bool {
+	if failed {
+		return false
+	}
+	return o.Latency == 0 || took <= time.Duration(o.Latency)
+}
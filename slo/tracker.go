@@ -0,0 +1,228 @@
+package slo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// BurnWindows are the windows burn rates are computed over. Short and long
+// windows are paired in alerts, e.g. 5m with 1h and 30m with 6h.
+var BurnWindows = []time.Duration{
+	5 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+}
+
+// Tracker counts good and total requests per method and derives
+// the error budget consumption of their objectives.
+type Tracker struct {
+	mtx     sync.Mutex
+	window  time.Duration
+	methods map[string]*tracked
+	order   []string
+	now     func() time.Time
+
+	good  metrics.Counter
+	total metrics.Counter
+}
+
+type tracked struct {
+	objective Objective
+	minutes   *ring // short-term buckets for the burn rates
+	hours     *ring // long-term buckets for the compliance window
+}
+
+// NewTracker returns a Tracker for the objectives in c and registers
+// its metrics in reg. The burn rates and remaining error budgets are
+// computed when scraped, so that they recover while no requests come in.
+func NewTracker(c Config, reg stdprometheus.Registerer) *Tracker {
+	window := time.Duration(c.Window)
+	if window == 0 {
+		window = DefaultWindow
+	}
+	longest := BurnWindows[len(BurnWindows)-1]
+
+	good := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: "my_group",
+		Subsystem: "string_service",
+		Name:      "slo_good_requests_total",
+		Help:      "Number of requests that met the method objective.",
+	}, []string{"method"})
+	total := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: "my_group",
+		Subsystem: "string_service",
+		Name:      "slo_requests_total",
+		Help:      "Number of requests evaluated against the method objective.",
+	}, []string{"method"})
+
+	t := &Tracker{
+		window:  window,
+		methods: map[string]*tracked{},
+		now:     time.Now,
+		good:    kitprometheus.NewCounter(good),
+		total:   kitprometheus.NewCounter(total),
+	}
+	for _, o := range c.Objectives {
+		t.methods[o.Method] = &tracked{
+			objective: o,
+			minutes:   newRing(time.Minute, int(longest/time.Minute)),
+			hours:     newRing(time.Hour, int((window+time.Hour-1)/time.Hour)),
+		}
+		t.order = append(t.order, o.Method)
+	}
+	sort.Strings(t.order)
+
+	reg.MustRegister(good, total, gauges{
+		tracker: t,
+		burnRate: stdprometheus.NewDesc(
+			"my_group_string_service_slo_burn_rate",
+			"Rate at which the error budget is consumed over the window.",
+			[]string{"method", "window"}, nil,
+		),
+		remaining: stdprometheus.NewDesc(
+			"my_group_string_service_slo_error_budget_remaining",
+			"Ratio of the error budget left in the compliance window.",
+			[]string{"method"}, nil,
+		),
+	})
+	return t
+}
+
+// Observe records the outcome of a single request. Requests to methods
+// without an objective are ignored.
+func (t *Tracker) Observe(method string, failed bool, took time.Duration) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	m, ok := t.methods[method]
+	if !ok {
+		return
+	}
+	now := t.now()
+	good := m.objective.good(failed, took)
+	m.minutes.add(now, good)
+	m.hours.add(now, good)
+	t.total.With("method", method).Add(1)
+	if good {
+		t.good.With("method", method).Add(1)
+	}
+}
+
+// Report summarizes all objectives as of now.
+func (t *Tracker) Report() Report {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	now := t.now()
+	r := Report{Window: Duration(t.window)}
+	for _, method := range t.order {
+		r.Objectives = append(r.Objectives, t.report(t.methods[method], now))
+	}
+	return r
+}
+
+func (t *Tracker) report(m *tracked, now time.Time) ObjectiveReport {
+	good, total := m.hours.sum(now, t.window)
+	r := ObjectiveReport{
+		Objective:  m.objective,
+		Good:       good,
+		Total:      total,
+		Compliance: ratio(good, total),
+		Budget:     budget(m.objective.Target, good, total),
+	}
+	for _, w := range BurnWindows {
+		good, total := m.minutes.sum(now, w)
+		r.BurnRates = append(r.BurnRates, BurnRate{
+			Window: Duration(w),
+			Rate:   burnRate(m.objective.Target, good, total),
+		})
+	}
+	return r
+}
+
+// gauges collects the burn rates and remaining error budgets of a Tracker
+// as of the time of the scrape.
+type gauges struct {
+	tracker   *Tracker
+	burnRate  *stdprometheus.Desc
+	remaining *stdprometheus.Desc
+}
+
+func (g gauges) Describe(ch chan<- *stdprometheus.Desc) {
+	ch <- g.burnRate
+	ch <- g.remaining
+}
+
+func (g gauges) Collect(ch chan<- stdprometheus.Metric) {
+	for _, r := range g.tracker.Report().Objectives {
+		for _, w := range r.BurnRates {
+			ch <- stdprometheus.MustNewConstMetric(g.burnRate, stdprometheus.GaugeValue, w.Rate, r.Method, w.Window.String())
+		}
+		ch <- stdprometheus.MustNewConstMetric(g.remaining, stdprometheus.GaugeValue, r.Budget.Remaining, r.Method)
+	}
+}
+
+// Report is the state of every objective tracked.
+type Report struct {
+	Window     Duration          `json:"window"`
+	Objectives []ObjectiveReport `json:"objectives"`
+}
+
+// ObjectiveReport is the state of a single objective
+// within the compliance window.
+type ObjectiveReport struct {
+	Objective
+	Good       float64    `json:"good"`
+	Total      float64    `json:"total"`
+	Compliance float64    `json:"compliance"`
+	Budget     Budget     `json:"error_budget"`
+	BurnRates  []BurnRate `json:"burn_rates"`
+}
+
+// Budget is the number of bad requests the objective tolerates
+// in the compliance window and how much of it was already used.
+type Budget struct {
+	Allowed   float64 `json:"allowed"`
+	Consumed  float64 `json:"consumed"`
+	Remaining float64 `json:"remaining"`
+}
+
+// BurnRate is the error ratio over a window relative to the one the
+// objective allows. A rate of 1 uses up the budget exactly at the end
+// of the compliance window.
+type BurnRate struct {
+	Window Duration `json:"window"`
+	Rate   float64  `json:"rate"`
+}
+
+func ratio(good, total float64) float64 {
+	if total == 0 {
+		return 1
+	}
+	return good / total
+}
+
+func budget(target, good, total float64) Budget {
+	b := Budget{
+		Allowed:   (1 - target) * total,
+		Consumed:  total - good,
+		Remaining: 1,
+	}
+	if b.Allowed > 0 {
+		b.Remaining = 1 - b.Consumed/b.Allowed
+	}
+	return b
+}
+
+func burnRate(target, good, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return (1 - good/total) / (1 - target)
+}
@@ -0,0 +1,51 @@
+package stringsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/audit"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// NewAuditMiddleware returns a MethodMiddleware that records every call
+// to the audit logger: the method, the Identity from the context as
+// resolved by the middlewares further in, the outcome, size of the input
+// and time of execution. Failures to write the record are reported to
+// logger and never fail the call itself.
+func NewAuditMiddleware(a *audit.Logger, logger log.Logger) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				ctx, latest := trackIdentity(ctx)
+				defer func(begin time.Time) {
+					id := *latest
+					rec := audit.Record{
+						Time:                begin.UTC(),
+						Method:              method,
+						ClaimedCaller:       id.Caller,
+						Tenant:              id.Tenant,
+						Remote:              id.Remote,
+						ClaimedForwardedFor: id.ForwardedFor,
+						Transport:           id.Transport,
+						Outcome:             audit.OutcomeSuccess,
+						TookMs:              float64(time.Since(begin)) / float64(time.Millisecond),
+					}
+					if r, ok := request.(inputRequest); ok {
+						rec.InputSize = len(r.input())
+					}
+					if failed := callError(response, err); failed != nil {
+						rec.Outcome, rec.Error = audit.OutcomeError, failed.Error()
+					}
+					if err := a.Log(rec); err != nil {
+						level.Error(logger).Log("during", "audit", "method", method, "err", err)
+					}
+				}(time.Now())
+
+				return next(ctx, request)
+			}
+		}
+	}
+}
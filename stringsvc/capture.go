@@ -0,0 +1,31 @@
+package stringsvc
+
+import (
+	"context"
+
+	"github.com/afrometal/go-kit-svc/capture"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// NewCaptureMiddleware returns a MethodMiddleware that records sampled
+// decoded requests and their responses to the capture recorder.
+// Failures to write the entry are reported to logger and never fail
+// the call itself.
+func NewCaptureMiddleware(r *capture.Recorder, logger log.Logger) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				if !r.Sample() {
+					return next(ctx, request)
+				}
+				response, err := next(ctx, request)
+				if err := r.Record(method, request, response, err); err != nil {
+					level.Error(logger).Log("during", "capture", "method", method, "err", err)
+				}
+				return response, err
+			}
+		}
+	}
+}
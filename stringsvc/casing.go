@@ -0,0 +1,264 @@
+package stringsvc
+
+// Splitting of identifiers and phrases into words and joining them
+// in the case styles of ConvertCase.
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Case styles of ConvertCase.
+const (
+	StyleSnake    = "snake"    // http_server_2
+	StyleCamel    = "camel"    // httpServer2
+	StylePascal   = "pascal"   // HttpServer2
+	StyleKebab    = "kebab"    // http-server-2
+	StyleConstant = "constant" // HTTP_SERVER_2
+)
+
+// CaseStyles lists the case styles of ConvertCase.
+var CaseStyles = []string{StyleSnake, StyleCamel, StylePascal, StyleKebab, StyleConstant}
+
+// convertCase joins the words of s in style.
+func convertCase(s, style string) (string, error) {
+	words := splitWords(s)
+	switch style {
+	case StyleSnake:
+		return joinWords(words, "_", strings.ToLower, strings.ToLower), nil
+	case StyleKebab:
+		return joinWords(words, "-", strings.ToLower, strings.ToLower), nil
+	case StyleConstant:
+		return joinWords(words, "_", strings.ToUpper, strings.ToUpper), nil
+	case StyleCamel:
+		return joinWords(words, "", strings.ToLower, capitalize), nil
+	case StylePascal:
+		return joinWords(words, "", capitalize, capitalize), nil
+	}
+	return "", invalidInput("unknown case style %q, want one of %s", style, strings.Join(CaseStyles, ", "))
+}
+
+// joinWords joins words with sep, the first one mapped by first
+// and the others by rest.
+func joinWords(words []string, sep string, first, rest func(string) string) string {
+	mapped := make([]string, len(words))
+	for i, w := range words {
+		if i == 0 {
+			mapped[i] = first(w)
+		} else {
+			mapped[i] = rest(w)
+		}
+	}
+	return strings.Join(mapped, sep)
+}
+
+// capitalize upper cases the first rune of w and lower cases the others.
+func capitalize(w string) string {
+	r, n := utf8.DecodeRuneInString(w)
+	if n == 0 {
+		return w
+	}
+	return string(unicode.ToTitle(r)) + strings.ToLower(w[n:])
+}
+
+// splitWords splits s into words. Words are separated by runes other than
+// letters, digits and combining marks, which stay with the rune they
+// follow, and start at an upper case letter following a lower
+// case one, at the last upper case letter of an acronym followed by a lower
+// case one, and where letters and digits meet: "HTTPServer2" is split into
+// "HTTP", "Server" and "2".
+func splitWords(s string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsMark(r) {
+			if len(word) > 0 {
+				word = append(word, r)
+			}
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if len(word) > 0 {
+			prev := lastBase(word)
+			next := nextBase(runes[i+1:])
+			switch {
+			case unicode.IsDigit(prev) != unicode.IsDigit(r):
+				flush()
+			case unicode.IsUpper(r) && !unicode.IsUpper(prev):
+				flush()
+			case unicode.IsUpper(r) && unicode.IsUpper(prev) && unicode.IsLower(next):
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// lastBase returns the last rune of word that is not a combining mark.
+func lastBase(word []rune) rune {
+	for i := len(word) - 1; i >= 0; i-- {
+		if !unicode.IsMark(word[i]) {
+			return word[i]
+		}
+	}
+	return 0
+}
+
+// nextBase returns the first rune of runes that is not a combining mark,
+// 0 if there is none.
+func nextBase(runes []rune) rune {
+	for _, r := range runes {
+		if !unicode.IsMark(r) {
+			return r
+		}
+	}
+	return 0
+}
+
+// Title case styles of TitleCase. Except in StylePlain, minor words are
+// lower cased unless they are the first or last word or follow a colon.
+const (
+	StylePlain   = "plain"   // every word capitalized
+	StyleAP      = "ap"      // Associated Press
+	StyleChicago = "chicago" // Chicago Manual of Style
+	StyleAPA     = "apa"     // American Psychological Association
+)
+
+// TitleStyles lists the title case styles of TitleCase.
+var TitleStyles = []string{StylePlain, StyleAP, StyleChicago, StyleAPA}
+
+// TitleCaseOptions of TitleCase. The zero value capitalizes every word
+// by the rules common to all languages.
+type TitleCaseOptions struct {
+	// Language is the BCP 47 tag of the language of the text, such as
+	// "tr" or "nl", whose capitalization rules are applied.
+	Language string
+	// Style is one of TitleStyles, StylePlain if empty.
+	Style string
+}
+
+// minorWords are the words lower cased by title case styles.
+var minorWords = map[string]map[string]bool{
+	StyleAP: wordSet(
+		"a", "an", "the",
+		"and", "but", "for", "nor", "or", "so", "yet",
+		"at", "by", "in", "of", "off", "on", "out", "per", "to", "up", "via",
+	),
+	StyleChicago: wordSet(
+		"a", "an", "the",
+		"and", "but", "for", "nor", "or",
+		"as", "to",
+		"about", "above", "across", "after", "against", "along", "among", "around", "at",
+		"before", "behind", "below", "beneath", "beside", "between", "beyond", "by",
+		"down", "during", "except", "from", "in", "inside", "into", "like", "near",
+		"of", "off", "on", "onto", "out", "outside", "over", "past", "per", "since",
+		"through", "throughout", "till", "toward", "towards", "under", "underneath",
+		"until", "up", "upon", "via", "with", "within", "without",
+	),
+	StyleAPA: wordSet(
+		"a", "an", "the",
+		"and", "as", "but", "for", "if", "nor", "or", "so", "yet",
+		"at", "by", "in", "of", "off", "on", "per", "to", "up", "via",
+	),
+}
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// titleCase capitalizes the words of s by the rules of the language and
+// the style in opts. Letters other than the first of a word are kept.
+func titleCase(s string, opts TitleCaseOptions) (string, error) {
+	tag := language.Und
+	if opts.Language != "" {
+		var err error
+		if tag, err = language.Parse(opts.Language); err != nil {
+			return "", invalidInput("invalid language tag %q: %v", opts.Language, err)
+		}
+	}
+	minor, ok := minorWords[opts.Style]
+	if !ok && opts.Style != "" && opts.Style != StylePlain {
+		return "", invalidInput("unknown title style %q, want one of %s", opts.Style, strings.Join(TitleStyles, ", "))
+	}
+	title, lower := cases.Title(tag, cases.NoLower), cases.Lower(tag)
+
+	tokens := splitTokens(s)
+	last := -1
+	for i, t := range tokens {
+		if t.word {
+			last = i
+		}
+	}
+	var b strings.Builder
+	first := true // next word starts the text or follows a colon
+	for i, t := range tokens {
+		switch {
+		case !t.word:
+			if strings.ContainsAny(t.text, ":—") {
+				first = true
+			}
+			b.WriteString(t.text)
+		case !first && i != last && minor[lower.String(t.text)]:
+			b.WriteString(lower.String(t.text))
+		default:
+			b.WriteString(title.String(t.text))
+			first = false
+		}
+	}
+	return b.String(), nil
+}
+
+// token is a word or the text between words.
+type token struct {
+	text string
+	word bool
+}
+
+// splitTokens splits s into words and the text between them. Words are
+// runs of letters, digits and marks, and include apostrophes between
+// letters, as in "don't".
+func splitTokens(s string) []token {
+	var (
+		tokens []token
+		start  int
+		inWord bool
+	)
+	for i, r := range s {
+		w := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+		if (r == '\'' || r == '’') && inWord {
+			next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+			w = unicode.IsLetter(next)
+		}
+		if i > 0 && w != inWord {
+			tokens = append(tokens, token{s[start:i], inWord})
+			start = i
+		}
+		inWord = w
+	}
+	if start < len(s) {
+		tokens = append(tokens, token{s[start:], inWord})
+	}
+	return tokens
+}
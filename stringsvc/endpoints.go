@@ -5,16 +5,204 @@ package stringsvc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/go-kit/kit/endpoint"
 )
 
+// Method names used to label endpoints in logs, metrics and records.
+const (
+	MethodTitleCase        = "title_case"
+	MethodRemoveWhitespace = "remove_whitespace"
+	MethodCount            = "count"
+	MethodConvertCase      = "convert_case"
+	MethodStats            = "stats"
+	MethodNormalize        = "normalize"
+	MethodSlugify          = "slugify"
+	MethodSimilarity       = "similarity"
+	MethodMatch            = "match"
+	MethodFindAll          = "find_all"
+	MethodReplaceAll       = "replace_all"
+	MethodWrap             = "wrap"
+	MethodTruncate         = "truncate"
+)
+
+// MethodVersion names the endpoint reporting the API versions served.
+// It is not a method of StringService and not listed in Methods.
+const MethodVersion = "version"
+
+// Methods lists the names of all methods.
+var Methods = []string{
+	MethodTitleCase,
+	MethodRemoveWhitespace,
+	MethodCount,
+	MethodConvertCase,
+	MethodStats,
+	MethodNormalize,
+	MethodSlugify,
+	MethodSimilarity,
+	MethodMatch,
+	MethodFindAll,
+	MethodReplaceAll,
+	MethodWrap,
+	MethodTruncate,
+}
+
+// IsMethod reports whether name is the name of a method.
+func IsMethod(name string) bool {
+	for _, m := range Methods {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Endpoints collects all endpoints that are required by StringService.
 // It's a helper struct to collect all of the endpoints into a single parameter.
 type Endpoints struct {
 	TitleCaseEndpoint        endpoint.Endpoint
 	RemoveWhitespaceEndpoint endpoint.Endpoint
 	CountEndpoint            endpoint.Endpoint
+	ConvertCaseEndpoint      endpoint.Endpoint
+	StatsEndpoint            endpoint.Endpoint
+	NormalizeEndpoint        endpoint.Endpoint
+	SlugifyEndpoint          endpoint.Endpoint
+	SimilarityEndpoint       endpoint.Endpoint
+	MatchEndpoint            endpoint.Endpoint
+	FindAllEndpoint          endpoint.Endpoint
+	ReplaceAllEndpoint       endpoint.Endpoint
+	WrapEndpoint             endpoint.Endpoint
+	TruncateEndpoint         endpoint.Endpoint
+	VersionEndpoint          endpoint.Endpoint
+}
+
+// MethodMiddleware returns an endpoint.Middleware for the named method.
+type MethodMiddleware func(method string) endpoint.Middleware
+
+// NewEndpoints returns Endpoints that invoke svc, each one wrapped with
+// the middlewares. The first middleware is the outermost one.
+// Useful in a server.
+func NewEndpoints(svc StringService, mws ...MethodMiddleware) Endpoints {
+	wrap := func(method string, e endpoint.Endpoint) endpoint.Endpoint {
+		for i := len(mws) - 1; i >= 0; i-- {
+			e = mws[i](method)(e)
+		}
+		return e
+	}
+	return Endpoints{
+		TitleCaseEndpoint:        wrap(MethodTitleCase, MakeTitleCaseEndpoint(svc)),
+		RemoveWhitespaceEndpoint: wrap(MethodRemoveWhitespace, MakeRemoveWhitespaceEndpoint(svc)),
+		CountEndpoint:            wrap(MethodCount, MakeCountEndpoint(svc)),
+		ConvertCaseEndpoint:      wrap(MethodConvertCase, MakeConvertCaseEndpoint(svc)),
+		StatsEndpoint:            wrap(MethodStats, MakeStatsEndpoint(svc)),
+		NormalizeEndpoint:        wrap(MethodNormalize, MakeNormalizeEndpoint(svc)),
+		SlugifyEndpoint:          wrap(MethodSlugify, MakeSlugifyEndpoint(svc)),
+		SimilarityEndpoint:       wrap(MethodSimilarity, MakeSimilarityEndpoint(svc)),
+		MatchEndpoint:            wrap(MethodMatch, MakeMatchEndpoint(svc)),
+		FindAllEndpoint:          wrap(MethodFindAll, MakeFindAllEndpoint(svc)),
+		ReplaceAllEndpoint:       wrap(MethodReplaceAll, MakeReplaceAllEndpoint(svc)),
+		WrapEndpoint:             wrap(MethodWrap, MakeWrapEndpoint(svc)),
+		TruncateEndpoint:         wrap(MethodTruncate, MakeTruncateEndpoint(svc)),
+		VersionEndpoint:          MakeVersionEndpoint(),
+	}
+}
+
+// Endpoint returns the endpoint of the named method or nil if there is none.
+func (e Endpoints) Endpoint(method string) endpoint.Endpoint {
+	switch method {
+	case MethodTitleCase:
+		return e.TitleCaseEndpoint
+	case MethodRemoveWhitespace:
+		return e.RemoveWhitespaceEndpoint
+	case MethodCount:
+		return e.CountEndpoint
+	case MethodConvertCase:
+		return e.ConvertCaseEndpoint
+	case MethodStats:
+		return e.StatsEndpoint
+	case MethodNormalize:
+		return e.NormalizeEndpoint
+	case MethodSlugify:
+		return e.SlugifyEndpoint
+	case MethodSimilarity:
+		return e.SimilarityEndpoint
+	case MethodMatch:
+		return e.MatchEndpoint
+	case MethodFindAll:
+		return e.FindAllEndpoint
+	case MethodReplaceAll:
+		return e.ReplaceAllEndpoint
+	case MethodWrap:
+		return e.WrapEndpoint
+	case MethodTruncate:
+		return e.TruncateEndpoint
+	case MethodVersion:
+		return e.VersionEndpoint
+	}
+	return nil
+}
+
+// DecodeJSONRequest decodes a JSON-encoded request of the named method,
+// as accepted by the HTTP transport, into the request of its endpoint.
+func DecodeJSONRequest(method string, data []byte) (interface{}, error) {
+	switch method {
+	case MethodTitleCase:
+		var req titleCaseRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodRemoveWhitespace:
+		var req removeWhitespaceRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodCount:
+		var req countRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodConvertCase:
+		var req convertCaseRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodStats:
+		var req statsRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodNormalize:
+		var req normalizeRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodSlugify:
+		var req slugifyRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodSimilarity:
+		var req similarityRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodMatch:
+		var req matchRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodFindAll:
+		var req findAllRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodReplaceAll:
+		var req replaceAllRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodWrap:
+		var req wrapRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	case MethodTruncate:
+		var req truncateRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
 }
 
 // MakeTitleCaseEndpoint returns an endpoint that invokes TitleCase on the StringService.
@@ -22,7 +210,7 @@ type Endpoints struct {
 func MakeTitleCaseEndpoint(svc StringService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(titleCaseRequest)
-		v, err := svc.TitleCase(ctx, req.S)
+		v, err := svc.TitleCase(ctx, req.S, TitleCaseOptions{Language: req.Language, Style: req.Style})
 		if err != nil {
 			return titleCaseResponse{v, err.Error()}, nil
 		}
@@ -36,79 +224,646 @@ func MakeTitleCaseEndpoint(svc StringService) endpoint.Endpoint {
 func MakeRemoveWhitespaceEndpoint(svc StringService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(removeWhitespaceRequest)
-		v, err := svc.RemoveWhitespace(ctx, req.S)
+		v, removed, err := svc.RemoveWhitespace(ctx, req.S, WhitespaceOptions{Mode: req.Mode, Class: req.Class})
 		if err != nil {
-			return removeWhitespaceResponse{v, err.Error()}, nil
+			return removeWhitespaceResponse{v, len(removed), removed, err.Error()}, nil
 		}
-		return removeWhitespaceResponse{v, ""}, nil
+		return removeWhitespaceResponse{v, len(removed), removed, ""}, nil
 	}
 }
 
-// MakeCountEndpoint returns an endpoint that invokes Count on the StringService.
+// MakeCountEndpoint returns an endpoint that invokes CountUnit on the
+// StringService, counting bytes like Count for requests without a unit.
 // Useful in a server.
 func MakeCountEndpoint(svc StringService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(countRequest)
-		v := svc.Count(ctx, req.S)
-		return countResponse{v}, nil
+		v, err := svc.CountUnit(ctx, req.S, req.Unit)
+		if err != nil {
+			return countResponse{v, err.Error()}, nil
+		}
+		return countResponse{v, ""}, nil
+	}
+}
+
+// MakeConvertCaseEndpoint returns an endpoint that invokes ConvertCase on the StringService.
+// Useful in a server.
+func MakeConvertCaseEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(convertCaseRequest)
+		v, err := svc.ConvertCase(ctx, req.S, req.Style)
+		if err != nil {
+			return convertCaseResponse{v, err.Error()}, nil
+		}
+		return convertCaseResponse{v, ""}, nil
+	}
+}
+
+// MakeStatsEndpoint returns an endpoint that invokes Stats on the StringService.
+// Useful in a server.
+func MakeStatsEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(statsRequest)
+		v, err := svc.Stats(ctx, req.S)
+		if err != nil {
+			return statsResponse{v, err.Error()}, nil
+		}
+		return statsResponse{v, ""}, nil
+	}
+}
+
+// MakeNormalizeEndpoint returns an endpoint that invokes Normalize on the StringService.
+// Useful in a server.
+func MakeNormalizeEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(normalizeRequest)
+		v, normalized, err := svc.Normalize(ctx, req.S, NormalizeOptions{Form: req.Form, Fold: req.Fold})
+		if err != nil {
+			return normalizeResponse{v, normalized, err.Error()}, nil
+		}
+		return normalizeResponse{v, normalized, ""}, nil
+	}
+}
+
+// MakeSlugifyEndpoint returns an endpoint that invokes Slugify on the StringService.
+// Useful in a server.
+func MakeSlugifyEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(slugifyRequest)
+		v, err := svc.Slugify(ctx, req.S, req.options())
+		if err != nil {
+			return slugifyResponse{v, err.Error()}, nil
+		}
+		return slugifyResponse{v, ""}, nil
+	}
+}
+
+// MakeSimilarityEndpoint returns an endpoint that invokes Similarity on the StringService.
+// Useful in a server.
+func MakeSimilarityEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(similarityRequest)
+		v, err := svc.Similarity(ctx, req.A, req.B, SimilarityOptions{
+			Algorithm:   req.Algorithm,
+			MaxDistance: req.MaxDistance,
+			Unit:        req.Unit,
+		})
+		if err != nil {
+			return similarityResponse{v, err.Error()}, nil
+		}
+		return similarityResponse{v, ""}, nil
+	}
+}
+
+// MakeMatchEndpoint returns an endpoint that invokes Match on the StringService.
+// Errors with a status of their own, such as a PatternError, are returned
+// to the transport rather than carried in the response.
+// Useful in a server.
+func MakeMatchEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(matchRequest)
+		v, err := svc.Match(ctx, req.S, req.Pattern)
+		if statusError(err) {
+			return nil, err
+		}
+		if err != nil {
+			return matchResponse{v, err.Error()}, nil
+		}
+		return matchResponse{v, ""}, nil
+	}
+}
+
+// MakeFindAllEndpoint returns an endpoint that invokes FindAll on the StringService.
+// Errors with a status of their own, such as a PatternError, are returned
+// to the transport rather than carried in the response.
+// Useful in a server.
+func MakeFindAllEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(findAllRequest)
+		v, err := svc.FindAll(ctx, req.S, req.Pattern, req.Limit)
+		if statusError(err) {
+			return nil, err
+		}
+		if err != nil {
+			return findAllResponse{v, err.Error()}, nil
+		}
+		return findAllResponse{v, ""}, nil
+	}
+}
+
+// MakeReplaceAllEndpoint returns an endpoint that invokes ReplaceAll on the StringService.
+// Errors with a status of their own, such as a PatternError, are returned
+// to the transport rather than carried in the response.
+// Useful in a server.
+func MakeReplaceAllEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replaceAllRequest)
+		v, err := svc.ReplaceAll(ctx, req.S, req.Pattern, req.Template, req.Limit)
+		if statusError(err) {
+			return nil, err
+		}
+		if err != nil {
+			return replaceAllResponse{v, err.Error()}, nil
+		}
+		return replaceAllResponse{v, ""}, nil
+	}
+}
+
+// MakeWrapEndpoint returns an endpoint that invokes Wrap on the StringService.
+// Useful in a server.
+func MakeWrapEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(wrapRequest)
+		v, err := svc.Wrap(ctx, req.S, req.options())
+		if err != nil {
+			return wrapResponse{v, err.Error()}, nil
+		}
+		return wrapResponse{v, ""}, nil
+	}
+}
+
+// MakeTruncateEndpoint returns an endpoint that invokes Truncate on the StringService.
+// Useful in a server.
+func MakeTruncateEndpoint(svc StringService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(truncateRequest)
+		v, truncated, err := svc.Truncate(ctx, req.S, req.options())
+		if err != nil {
+			return truncateResponse{v, truncated, err.Error()}, nil
+		}
+		return truncateResponse{v, truncated, ""}, nil
+	}
+}
+
+// MakeVersionEndpoint returns an endpoint that reports the API versions
+// served by this package.
+// Useful in a server.
+func MakeVersionEndpoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		return versionResponse{Served}, nil
 	}
 }
 
 // TitleCase implements StringService.
 // Useful in a client.
-func (e Endpoints) TitleCase(ctx context.Context, s string) (string, error) {
-	req := titleCaseRequest{S: s}
+func (e Endpoints) TitleCase(ctx context.Context, s string, opts TitleCaseOptions) (string, error) {
+	req := titleCaseRequest{S: s, Language: opts.Language, Style: opts.Style}
 	res, err := e.TitleCaseEndpoint(ctx, req)
 	if err != nil {
 		return "", err
 	}
-	return res.(titleCaseResponse).V, nil
+	resp := res.(titleCaseResponse)
+	return resp.V, resp.Failed()
 }
 
 // RemoveWhitespace implements StringService.
 // Useful in a client.
-func (e Endpoints) RemoveWhitespace(ctx context.Context, s string) (string, error) {
-	req := removeWhitespaceRequest{S: s}
+func (e Endpoints) RemoveWhitespace(ctx context.Context, s string, opts WhitespaceOptions) (string, []int, error) {
+	req := removeWhitespaceRequest{S: s, Mode: opts.Mode, Class: opts.Class}
 	res, err := e.RemoveWhitespaceEndpoint(ctx, req)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return res.(removeWhitespaceResponse).V, nil
+	resp := res.(removeWhitespaceResponse)
+	return resp.V, resp.Positions, resp.Failed()
 }
 
-// Count implements StringService.
+// Count implements StringService. Failed calls count 0, see CountUnit
+// for their errors.
 // Useful in a client.
 func (e Endpoints) Count(ctx context.Context, s string) int {
-	req := countRequest{S: s}
+	n, _ := e.CountUnit(ctx, s, "")
+	return n
+}
+
+// CountUnit implements StringService.
+// Useful in a client.
+func (e Endpoints) CountUnit(ctx context.Context, s, unit string) (int, error) {
+	req := countRequest{S: s, Unit: unit}
 	res, err := e.CountEndpoint(ctx, req)
 	if err != nil {
-		return 0
+		return 0, err
+	}
+	resp := res.(countResponse)
+	return resp.V, resp.Failed()
+}
+
+// ConvertCase implements StringService.
+// Useful in a client.
+func (e Endpoints) ConvertCase(ctx context.Context, s, style string) (string, error) {
+	req := convertCaseRequest{S: s, Style: style}
+	res, err := e.ConvertCaseEndpoint(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	resp := res.(convertCaseResponse)
+	return resp.V, resp.Failed()
+}
+
+// Stats implements StringService.
+// Useful in a client.
+func (e Endpoints) Stats(ctx context.Context, s string) (TextStats, error) {
+	req := statsRequest{S: s}
+	res, err := e.StatsEndpoint(ctx, req)
+	if err != nil {
+		return TextStats{}, err
+	}
+	resp := res.(statsResponse)
+	return resp.V, resp.Failed()
+}
+
+// Normalize implements StringService.
+// Useful in a client.
+func (e Endpoints) Normalize(ctx context.Context, s string, opts NormalizeOptions) (string, bool, error) {
+	req := normalizeRequest{S: s, Form: opts.Form, Fold: opts.Fold}
+	res, err := e.NormalizeEndpoint(ctx, req)
+	if err != nil {
+		return "", false, err
+	}
+	resp := res.(normalizeResponse)
+	return resp.V, resp.Normalized, resp.Failed()
+}
+
+// Slugify implements StringService.
+// Useful in a client.
+func (e Endpoints) Slugify(ctx context.Context, s string, opts SlugOptions) (string, error) {
+	req := slugifyRequest{
+		S:         s,
+		Separator: opts.Separator,
+		MaxLength: opts.MaxLength,
+		StopWords: opts.StopWords,
+		Existing:  opts.Existing,
+	}
+	res, err := e.SlugifyEndpoint(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	resp := res.(slugifyResponse)
+	return resp.V, resp.Failed()
+}
+
+// Similarity implements StringService.
+// Useful in a client.
+func (e Endpoints) Similarity(ctx context.Context, a, b string, opts SimilarityOptions) (SimilarityResult, error) {
+	req := similarityRequest{
+		A:           a,
+		B:           b,
+		Algorithm:   opts.Algorithm,
+		MaxDistance: opts.MaxDistance,
+		Unit:        opts.Unit,
+	}
+	res, err := e.SimilarityEndpoint(ctx, req)
+	if err != nil {
+		return SimilarityResult{}, err
+	}
+	resp := res.(similarityResponse)
+	return resp.SimilarityResult, resp.Failed()
+}
+
+// Match implements StringService.
+// Useful in a client.
+func (e Endpoints) Match(ctx context.Context, s, pattern string) (bool, error) {
+	req := matchRequest{S: s, Pattern: pattern}
+	res, err := e.MatchEndpoint(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	resp := res.(matchResponse)
+	return resp.V, resp.Failed()
+}
+
+// FindAll implements StringService.
+// Useful in a client.
+func (e Endpoints) FindAll(ctx context.Context, s, pattern string, limit int) (FindAllResult, error) {
+	req := findAllRequest{S: s, Pattern: pattern, Limit: limit}
+	res, err := e.FindAllEndpoint(ctx, req)
+	if err != nil {
+		return FindAllResult{}, err
+	}
+	resp := res.(findAllResponse)
+	return resp.FindAllResult, resp.Failed()
+}
+
+// ReplaceAll implements StringService.
+// Useful in a client.
+func (e Endpoints) ReplaceAll(ctx context.Context, s, pattern, template string, limit int) (ReplaceAllResult, error) {
+	req := replaceAllRequest{S: s, Pattern: pattern, Template: template, Limit: limit}
+	res, err := e.ReplaceAllEndpoint(ctx, req)
+	if err != nil {
+		return ReplaceAllResult{}, err
+	}
+	resp := res.(replaceAllResponse)
+	return resp.ReplaceAllResult, resp.Failed()
+}
+
+// Wrap implements StringService.
+// Useful in a client.
+func (e Endpoints) Wrap(ctx context.Context, s string, opts WrapOptions) (string, error) {
+	req := wrapRequest{
+		S:         s,
+		Width:     opts.Width,
+		LongWords: opts.LongWords,
+		Align:     opts.Align,
+		Indent:    opts.Indent,
+		Hanging:   opts.Hanging,
+	}
+	res, err := e.WrapEndpoint(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	resp := res.(wrapResponse)
+	return resp.V, resp.Failed()
+}
+
+// Truncate implements StringService.
+// Useful in a client.
+func (e Endpoints) Truncate(ctx context.Context, s string, opts TruncateOptions) (string, bool, error) {
+	req := truncateRequest{
+		S:            s,
+		Limit:        opts.Limit,
+		Unit:         opts.Unit,
+		WordBoundary: opts.WordBoundary,
+		Ellipsis:     opts.Ellipsis,
+	}
+	res, err := e.TruncateEndpoint(ctx, req)
+	if err != nil {
+		return "", false, err
+	}
+	resp := res.(truncateResponse)
+	return resp.V, resp.Truncated, resp.Failed()
+}
+
+// Version returns the API versions served by the server.
+// Useful in a client.
+func (e Endpoints) Version(ctx context.Context) (VersionInfo, error) {
+	res, err := e.VersionEndpoint(ctx, versionRequest{})
+	if err != nil {
+		return VersionInfo{}, err
 	}
-	return res.(countResponse).V
+	return res.(versionResponse).VersionInfo, nil
+}
+
+// inputRequest is implemented by requests to report the text they carry.
+type inputRequest interface {
+	input() string
 }
 
 type titleCaseRequest struct {
-	S string `json:"s"`
+	S        string `json:"s"`
+	Language string `json:"language,omitempty"`
+	Style    string `json:"style,omitempty"`
 }
 
+func (r titleCaseRequest) input() string { return r.S }
+
 type titleCaseResponse struct {
 	V   string `json:"v"`
 	Err string `json:"err,omitempty"`
 }
 
+// Failed implements endpoint.Failer.
+func (r titleCaseResponse) Failed() error { return failure(r.Err) }
+
 type removeWhitespaceRequest struct {
-	S string `json:"s"`
+	S     string `json:"s"`
+	Mode  string `json:"mode,omitempty"`
+	Class string `json:"class,omitempty"`
 }
 
+func (r removeWhitespaceRequest) input() string { return r.S }
+
 type removeWhitespaceResponse struct {
+	V         string `json:"v"`
+	Removed   int    `json:"removed"`
+	Positions []int  `json:"positions,omitempty"`
+	Err       string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r removeWhitespaceResponse) Failed() error { return failure(r.Err) }
+
+type countRequest struct {
+	S    string `json:"s"`
+	Unit string `json:"unit,omitempty"`
+}
+
+func (r countRequest) input() string { return r.S }
+
+type countResponse struct {
+	V   int    `json:"v"`
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r countResponse) Failed() error { return failure(r.Err) }
+
+type convertCaseRequest struct {
+	S     string `json:"s"`
+	Style string `json:"style"`
+}
+
+func (r convertCaseRequest) input() string { return r.S }
+
+type convertCaseResponse struct {
 	V   string `json:"v"`
 	Err string `json:"err,omitempty"`
 }
 
-type countRequest struct {
+// Failed implements endpoint.Failer.
+func (r convertCaseResponse) Failed() error { return failure(r.Err) }
+
+type statsRequest struct {
 	S string `json:"s"`
 }
 
-type countResponse struct {
-	V int `json:"v"`
+func (r statsRequest) input() string { return r.S }
+
+type statsResponse struct {
+	V   TextStats `json:"v"`
+	Err string    `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r statsResponse) Failed() error { return failure(r.Err) }
+
+type normalizeRequest struct {
+	S    string `json:"s"`
+	Form string `json:"form,omitempty"`
+	Fold bool   `json:"fold,omitempty"`
+}
+
+func (r normalizeRequest) input() string { return r.S }
+
+type normalizeResponse struct {
+	V          string `json:"v"`
+	Normalized bool   `json:"normalized"`
+	Err        string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r normalizeResponse) Failed() error { return failure(r.Err) }
+
+type slugifyRequest struct {
+	S         string   `json:"s"`
+	Separator string   `json:"separator,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+	StopWords []string `json:"stop_words,omitempty"`
+	Existing  []string `json:"existing,omitempty"`
+}
+
+func (r slugifyRequest) input() string { return r.S }
+
+func (r slugifyRequest) options() SlugOptions {
+	return SlugOptions{Separator: r.Separator, MaxLength: r.MaxLength, StopWords: r.StopWords, Existing: r.Existing}
+}
+
+type slugifyResponse struct {
+	V   string `json:"v"`
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r slugifyResponse) Failed() error { return failure(r.Err) }
+
+type similarityRequest struct {
+	A           string  `json:"a"`
+	B           string  `json:"b"`
+	Algorithm   string  `json:"algorithm,omitempty"`
+	MaxDistance float64 `json:"max_distance,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+}
+
+// input returns both strings compared, one per line.
+func (r similarityRequest) input() string { return r.A + "\n" + r.B }
+
+type similarityResponse struct {
+	SimilarityResult
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r similarityResponse) Failed() error { return failure(r.Err) }
+
+type matchRequest struct {
+	S       string `json:"s"`
+	Pattern string `json:"pattern"`
+}
+
+func (r matchRequest) input() string { return r.S }
+
+type matchResponse struct {
+	V   bool   `json:"v"`
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r matchResponse) Failed() error { return failure(r.Err) }
+
+type findAllRequest struct {
+	S       string `json:"s"`
+	Pattern string `json:"pattern"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+func (r findAllRequest) input() string { return r.S }
+
+type findAllResponse struct {
+	FindAllResult
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r findAllResponse) Failed() error { return failure(r.Err) }
+
+type replaceAllRequest struct {
+	S        string `json:"s"`
+	Pattern  string `json:"pattern"`
+	Template string `json:"template"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+func (r replaceAllRequest) input() string { return r.S }
+
+type replaceAllResponse struct {
+	ReplaceAllResult
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r replaceAllResponse) Failed() error { return failure(r.Err) }
+
+type wrapRequest struct {
+	S         string `json:"s"`
+	Width     int    `json:"width,omitempty"`
+	LongWords string `json:"long_words,omitempty"`
+	Align     string `json:"align,omitempty"`
+	Indent    int    `json:"indent,omitempty"`
+	Hanging   int    `json:"hanging,omitempty"`
+}
+
+func (r wrapRequest) input() string { return r.S }
+
+func (r wrapRequest) options() WrapOptions {
+	return WrapOptions{Width: r.Width, LongWords: r.LongWords, Align: r.Align, Indent: r.Indent, Hanging: r.Hanging}
+}
+
+type wrapResponse struct {
+	V   string `json:"v"`
+	Err string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r wrapResponse) Failed() error { return failure(r.Err) }
+
+type truncateRequest struct {
+	S            string `json:"s"`
+	Limit        int    `json:"limit"`
+	Unit         string `json:"unit,omitempty"`
+	WordBoundary bool   `json:"word_boundary,omitempty"`
+	Ellipsis     string `json:"ellipsis,omitempty"`
+}
+
+func (r truncateRequest) input() string { return r.S }
+
+func (r truncateRequest) options() TruncateOptions {
+	return TruncateOptions{Limit: r.Limit, Unit: r.Unit, WordBoundary: r.WordBoundary, Ellipsis: r.Ellipsis}
+}
+
+type truncateResponse struct {
+	V         string `json:"v"`
+	Truncated bool   `json:"truncated"`
+	Err       string `json:"err,omitempty"`
+}
+
+// Failed implements endpoint.Failer.
+func (r truncateResponse) Failed() error { return failure(r.Err) }
+
+type versionRequest struct{}
+
+type versionResponse struct {
+	VersionInfo
+}
+
+// callError returns the error of an endpoint call, either the one returned
+// by the endpoint or the business logic error carried in its response.
+func callError(response interface{}, err error) error {
+	if f, ok := response.(endpoint.Failer); ok && err == nil {
+		return f.Failed()
+	}
+	return err
+}
+
+// statusError reports whether err carries a transport status of its own,
+// as opposed to the business logic errors carried in responses.
+func statusError(err error) bool {
+	_, ok := err.(interface{ StatusCode() int })
+	return ok
+}
+
+// failure turns an error message carried in a response back into an error.
+func failure(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
 }
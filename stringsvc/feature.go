@@ -0,0 +1,56 @@
+package stringsvc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/afrometal/go-kit-svc/feature"
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewFeatureMiddleware returns a MethodMiddleware that evaluates the flag
+// of the method for the tenant and caller of every call, rejecting calls
+// of disabled methods with a DisabledError.
+func NewFeatureMiddleware(f *feature.Flags) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				id, _ := IdentityFromContext(ctx)
+				if d := f.Evaluate(method, id.Tenant, id.Caller); !d.Enabled {
+					return nil, DisabledError{Method: method, Tenant: id.Tenant, Reason: d.Reason}
+				}
+				return next(ctx, request)
+			}
+		}
+	}
+}
+
+// DisabledError is returned for calls of methods switched off by their
+// feature flag. It is answered with HTTP status 404 and gRPC status
+// Unimplemented.
+type DisabledError struct {
+	Method string
+	Tenant string
+	Reason string
+}
+
+func (e DisabledError) Error() string {
+	switch {
+	case e.Reason == feature.ReasonTenant:
+		return fmt.Sprintf("method %s is disabled for tenant %q", e.Method, e.Tenant)
+	case e.Reason == feature.ReasonPercentage:
+		return fmt.Sprintf("method %s is not yet enabled for this caller", e.Method)
+	}
+	return fmt.Sprintf("method %s is disabled", e.Method)
+}
+
+// StatusCode implements transport/http.StatusCoder.
+func (e DisabledError) StatusCode() int { return http.StatusNotFound }
+
+// GRPCStatus lets the gRPC server answer with the Unimplemented status.
+func (e DisabledError) GRPCStatus() *status.Status {
+	return status.New(codes.Unimplemented, e.Error())
+}
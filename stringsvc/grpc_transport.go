@@ -7,15 +7,18 @@ import (
 	"context"
 
 	"github.com/afrometal/go-kit-svc/stringsvc/proto"
+	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	oldcontext "golang.org/x/net/context"
+	"google.golang.org/grpc/status"
 )
 
 // MakeGRPC returns a set of handlers available as a gRPC StringServer.
 func MakeGRPCServer(endpoints Endpoints, logger log.Logger) proto.StringServer {
 	options := []grpctransport.ServerOption{
 		grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(GRPCToIdentity, GRPCToVersion),
 	}
 	return &grpcServer{
 		titleCase: grpctransport.NewServer(
@@ -36,6 +39,72 @@ func MakeGRPCServer(endpoints Endpoints, logger log.Logger) proto.StringServer {
 			EncodeGRPCCountResponse,
 			options...,
 		),
+		convertCase: grpctransport.NewServer(
+			endpoints.ConvertCaseEndpoint,
+			DecodeGRPCConvertCaseRequest,
+			EncodeGRPCConvertCaseResponse,
+			options...,
+		),
+		stats: grpctransport.NewServer(
+			endpoints.StatsEndpoint,
+			DecodeGRPCStatsRequest,
+			EncodeGRPCStatsResponse,
+			options...,
+		),
+		normalize: grpctransport.NewServer(
+			endpoints.NormalizeEndpoint,
+			DecodeGRPCNormalizeRequest,
+			EncodeGRPCNormalizeResponse,
+			options...,
+		),
+		slugify: grpctransport.NewServer(
+			endpoints.SlugifyEndpoint,
+			DecodeGRPCSlugifyRequest,
+			EncodeGRPCSlugifyResponse,
+			options...,
+		),
+		similarity: grpctransport.NewServer(
+			endpoints.SimilarityEndpoint,
+			DecodeGRPCSimilarityRequest,
+			EncodeGRPCSimilarityResponse,
+			options...,
+		),
+		match: grpctransport.NewServer(
+			endpoints.MatchEndpoint,
+			DecodeGRPCMatchRequest,
+			EncodeGRPCMatchResponse,
+			options...,
+		),
+		findAll: grpctransport.NewServer(
+			endpoints.FindAllEndpoint,
+			DecodeGRPCFindAllRequest,
+			EncodeGRPCFindAllResponse,
+			options...,
+		),
+		replaceAll: grpctransport.NewServer(
+			endpoints.ReplaceAllEndpoint,
+			DecodeGRPCReplaceAllRequest,
+			EncodeGRPCReplaceAllResponse,
+			options...,
+		),
+		wrap: grpctransport.NewServer(
+			endpoints.WrapEndpoint,
+			DecodeGRPCWrapRequest,
+			EncodeGRPCWrapResponse,
+			options...,
+		),
+		truncate: grpctransport.NewServer(
+			endpoints.TruncateEndpoint,
+			DecodeGRPCTruncateRequest,
+			EncodeGRPCTruncateResponse,
+			options...,
+		),
+		version: grpctransport.NewServer(
+			endpoints.VersionEndpoint,
+			DecodeGRPCVersionRequest,
+			EncodeGRPCVersionResponse,
+			options...,
+		),
 	}
 }
 
@@ -43,6 +112,17 @@ type grpcServer struct {
 	titleCase        grpctransport.Handler
 	removeWhitespace grpctransport.Handler
 	count            grpctransport.Handler
+	convertCase      grpctransport.Handler
+	stats            grpctransport.Handler
+	normalize        grpctransport.Handler
+	slugify          grpctransport.Handler
+	similarity       grpctransport.Handler
+	match            grpctransport.Handler
+	findAll          grpctransport.Handler
+	replaceAll       grpctransport.Handler
+	wrap             grpctransport.Handler
+	truncate         grpctransport.Handler
+	version          grpctransport.Handler
 }
 
 func (s *grpcServer) TitleCase(ctx oldcontext.Context, req *proto.TitleCaseRequest) (*proto.TitleCaseResponse, error) {
@@ -69,12 +149,100 @@ func (s *grpcServer) Count(ctx oldcontext.Context, req *proto.CountRequest) (*pr
 	return rep.(*proto.CountResponse), nil
 }
 
+func (s *grpcServer) ConvertCase(ctx oldcontext.Context, req *proto.ConvertCaseRequest) (*proto.ConvertCaseResponse, error) {
+	_, rep, err := s.convertCase.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.ConvertCaseResponse), nil
+}
+
+func (s *grpcServer) Stats(ctx oldcontext.Context, req *proto.StatsRequest) (*proto.StatsResponse, error) {
+	_, rep, err := s.stats.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.StatsResponse), nil
+}
+
+func (s *grpcServer) Normalize(ctx oldcontext.Context, req *proto.NormalizeRequest) (*proto.NormalizeResponse, error) {
+	_, rep, err := s.normalize.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.NormalizeResponse), nil
+}
+
+func (s *grpcServer) Slugify(ctx oldcontext.Context, req *proto.SlugifyRequest) (*proto.SlugifyResponse, error) {
+	_, rep, err := s.slugify.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.SlugifyResponse), nil
+}
+
+func (s *grpcServer) Similarity(ctx oldcontext.Context, req *proto.SimilarityRequest) (*proto.SimilarityResponse, error) {
+	_, rep, err := s.similarity.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.SimilarityResponse), nil
+}
+
+func (s *grpcServer) Match(ctx oldcontext.Context, req *proto.MatchRequest) (*proto.MatchResponse, error) {
+	_, rep, err := s.match.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.MatchResponse), nil
+}
+
+func (s *grpcServer) FindAll(ctx oldcontext.Context, req *proto.FindAllRequest) (*proto.FindAllResponse, error) {
+	_, rep, err := s.findAll.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.FindAllResponse), nil
+}
+
+func (s *grpcServer) ReplaceAll(ctx oldcontext.Context, req *proto.ReplaceAllRequest) (*proto.ReplaceAllResponse, error) {
+	_, rep, err := s.replaceAll.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.ReplaceAllResponse), nil
+}
+
+func (s *grpcServer) Wrap(ctx oldcontext.Context, req *proto.WrapRequest) (*proto.WrapResponse, error) {
+	_, rep, err := s.wrap.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.WrapResponse), nil
+}
+
+func (s *grpcServer) Truncate(ctx oldcontext.Context, req *proto.TruncateRequest) (*proto.TruncateResponse, error) {
+	_, rep, err := s.truncate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.TruncateResponse), nil
+}
+
+func (s *grpcServer) Version(ctx oldcontext.Context, req *proto.VersionRequest) (*proto.VersionResponse, error) {
+	_, rep, err := s.version.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*proto.VersionResponse), nil
+}
+
 // DecodeGRPCTitleCaseRequest is a transport/grpc.DecodeRequestFunc that converts a
 // gRPC request to a user-domain request.
 // Useful in a server.
 func DecodeGRPCTitleCaseRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*proto.TitleCaseRequest)
-	return titleCaseRequest{S: req.S}, nil
+	return titleCaseRequest{S: req.S, Language: req.Language, Style: req.Style}, nil
 }
 
 // DecodeGRPCRemoveWhitespaceRequest is a transport/grpc.DecodeRequestFunc that converts a
@@ -82,7 +250,7 @@ func DecodeGRPCTitleCaseRequest(_ context.Context, grpcReq interface{}) (interfa
 // Useful in a server.
 func DecodeGRPCRemoveWhitespaceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*proto.RemoveWhitespaceRequest)
-	return removeWhitespaceRequest{S: req.S}, nil
+	return removeWhitespaceRequest{S: req.S, Mode: req.Mode, Class: req.Class}, nil
 }
 
 // DecodeGRPCCountRequest is a transport/grpc.DecodeRequestFunc that converts a
@@ -90,7 +258,119 @@ func DecodeGRPCRemoveWhitespaceRequest(_ context.Context, grpcReq interface{}) (
 // Useful in a server.
 func DecodeGRPCCountRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*proto.CountRequest)
-	return countRequest{S: req.S}, nil
+	return countRequest{S: req.S, Unit: req.Unit}, nil
+}
+
+// DecodeGRPCConvertCaseRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCConvertCaseRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.ConvertCaseRequest)
+	return convertCaseRequest{S: req.S, Style: req.Style}, nil
+}
+
+// DecodeGRPCStatsRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCStatsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.StatsRequest)
+	return statsRequest{S: req.S}, nil
+}
+
+// DecodeGRPCNormalizeRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCNormalizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.NormalizeRequest)
+	return normalizeRequest{S: req.S, Form: req.Form, Fold: req.Fold}, nil
+}
+
+// DecodeGRPCSlugifyRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCSlugifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.SlugifyRequest)
+	return slugifyRequest{
+		S:         req.S,
+		Separator: req.Separator,
+		MaxLength: int(req.MaxLength),
+		StopWords: req.StopWords,
+		Existing:  req.Existing,
+	}, nil
+}
+
+// DecodeGRPCSimilarityRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCSimilarityRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.SimilarityRequest)
+	return similarityRequest{
+		A:           req.A,
+		B:           req.B,
+		Algorithm:   req.Algorithm,
+		MaxDistance: req.MaxDistance,
+		Unit:        req.Unit,
+	}, nil
+}
+
+// DecodeGRPCMatchRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCMatchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.MatchRequest)
+	return matchRequest{S: req.S, Pattern: req.Pattern}, nil
+}
+
+// DecodeGRPCFindAllRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCFindAllRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.FindAllRequest)
+	return findAllRequest{S: req.S, Pattern: req.Pattern, Limit: int(req.Limit)}, nil
+}
+
+// DecodeGRPCReplaceAllRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCReplaceAllRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.ReplaceAllRequest)
+	return replaceAllRequest{S: req.S, Pattern: req.Pattern, Template: req.Template, Limit: int(req.Limit)}, nil
+}
+
+// DecodeGRPCWrapRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCWrapRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.WrapRequest)
+	return wrapRequest{
+		S:         req.S,
+		Width:     int(req.Width),
+		LongWords: req.LongWords,
+		Align:     req.Align,
+		Indent:    int(req.Indent),
+		Hanging:   int(req.Hanging),
+	}, nil
+}
+
+// DecodeGRPCTruncateRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCTruncateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*proto.TruncateRequest)
+	return truncateRequest{
+		S:            req.S,
+		Limit:        int(req.Limit),
+		Unit:         req.Unit,
+		WordBoundary: req.WordBoundary,
+		Ellipsis:     req.Ellipsis,
+	}, nil
+}
+
+// DecodeGRPCVersionRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request.
+// Useful in a server.
+func DecodeGRPCVersionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return versionRequest{}, nil
 }
 
 // DecodeGRPCTitleCaseResponse is a transport/grpc.DecodeResponseFunc that converts a
@@ -106,7 +386,11 @@ func DecodeGRPCTitleCaseResponse(_ context.Context, grpcRes interface{}) (interf
 // Useful in a client.
 func DecodeGRPCRemoveWhitespaceResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*proto.RemoveWhitespaceResponse)
-	return removeWhitespaceResponse{V: res.V, Err: res.Err}, nil
+	var positions []int
+	for _, p := range res.Positions {
+		positions = append(positions, int(p))
+	}
+	return removeWhitespaceResponse{V: res.V, Removed: int(res.Removed), Positions: positions, Err: res.Err}, nil
 }
 
 // DecodeGRPCCountResponse is a transport/grpc.DecodeResponseFunc that converts a
@@ -114,7 +398,107 @@ func DecodeGRPCRemoveWhitespaceResponse(_ context.Context, grpcRes interface{})
 // Useful in a client.
 func DecodeGRPCCountResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*proto.CountResponse)
-	return countResponse{V: int(res.V)}, nil
+	return countResponse{V: int(res.V), Err: res.Err}, nil
+}
+
+// DecodeGRPCConvertCaseResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCConvertCaseResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.ConvertCaseResponse)
+	return convertCaseResponse{V: res.V, Err: res.Err}, nil
+}
+
+// DecodeGRPCStatsResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCStatsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.StatsResponse)
+	return statsResponse{V: TextStats{
+		Bytes:     int(res.Bytes),
+		Runes:     int(res.Runes),
+		Graphemes: int(res.Graphemes),
+		Words:     int(res.Words),
+		Lines:     int(res.Lines),
+		Sentences: int(res.Sentences),
+		Width:     int(res.Width),
+	}, Err: res.Err}, nil
+}
+
+// DecodeGRPCNormalizeResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCNormalizeResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.NormalizeResponse)
+	return normalizeResponse{V: res.V, Normalized: res.Normalized, Err: res.Err}, nil
+}
+
+// DecodeGRPCSlugifyResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCSlugifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.SlugifyResponse)
+	return slugifyResponse{V: res.V, Err: res.Err}, nil
+}
+
+// DecodeGRPCSimilarityResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCSimilarityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.SimilarityResponse)
+	return similarityResponse{SimilarityResult{Distance: res.Distance, Score: res.Score, Exceeded: res.Exceeded}, res.Err}, nil
+}
+
+// DecodeGRPCMatchResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCMatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.MatchResponse)
+	return matchResponse{V: res.V, Err: res.Err}, nil
+}
+
+// DecodeGRPCFindAllResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCFindAllResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.FindAllResponse)
+	matches := []RegexMatch{}
+	for _, m := range res.Matches {
+		matches = append(matches, regexMatchFromProto(m))
+	}
+	return findAllResponse{FindAllResult{Matches: matches, Truncated: res.Truncated}, res.Err}, nil
+}
+
+// DecodeGRPCReplaceAllResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCReplaceAllResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.ReplaceAllResponse)
+	return replaceAllResponse{ReplaceAllResult{V: res.V, Replaced: int(res.Replaced), Truncated: res.Truncated}, res.Err}, nil
+}
+
+// DecodeGRPCWrapResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCWrapResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.WrapResponse)
+	return wrapResponse{V: res.V, Err: res.Err}, nil
+}
+
+// DecodeGRPCTruncateResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCTruncateResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.TruncateResponse)
+	return truncateResponse{V: res.V, Truncated: res.Truncated, Err: res.Err}, nil
+}
+
+// DecodeGRPCVersionResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC reply to a user-domain response.
+// Useful in a client.
+func DecodeGRPCVersionResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*proto.VersionResponse)
+	return versionResponse{VersionInfo{Version: int(res.Version), MinVersion: int(res.MinVersion)}}, nil
 }
 
 // EncodeGRPCTitleCaseResponse is a transport/grpc.EncodeResponseFunc that converts a
@@ -130,7 +514,11 @@ func EncodeGRPCTitleCaseResponse(_ context.Context, response interface{}) (inter
 // Useful in a server.
 func EncodeGRPCRemoveWhitespaceResponse(_ context.Context, response interface{}) (interface{}, error) {
 	resp := response.(removeWhitespaceResponse)
-	return &proto.RemoveWhitespaceResponse{V: resp.V, Err: resp.Err}, nil
+	var positions []int64
+	for _, p := range resp.Positions {
+		positions = append(positions, int64(p))
+	}
+	return &proto.RemoveWhitespaceResponse{V: resp.V, Removed: int64(resp.Removed), Positions: positions, Err: resp.Err}, nil
 }
 
 // EncodeGRPCCountResponse is a transport/grpc.EncodeResponseFunc that converts a
@@ -138,7 +526,108 @@ func EncodeGRPCRemoveWhitespaceResponse(_ context.Context, response interface{})
 // Useful in a server.
 func EncodeGRPCCountResponse(_ context.Context, response interface{}) (interface{}, error) {
 	resp := response.(countResponse)
-	return &proto.CountResponse{V: int64(resp.V)}, nil
+	return &proto.CountResponse{V: int64(resp.V), Err: resp.Err}, nil
+}
+
+// EncodeGRPCConvertCaseResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCConvertCaseResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(convertCaseResponse)
+	return &proto.ConvertCaseResponse{V: resp.V, Err: resp.Err}, nil
+}
+
+// EncodeGRPCStatsResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCStatsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(statsResponse)
+	return &proto.StatsResponse{
+		Bytes:     int64(resp.V.Bytes),
+		Runes:     int64(resp.V.Runes),
+		Graphemes: int64(resp.V.Graphemes),
+		Words:     int64(resp.V.Words),
+		Lines:     int64(resp.V.Lines),
+		Sentences: int64(resp.V.Sentences),
+		Width:     int64(resp.V.Width),
+		Err:       resp.Err,
+	}, nil
+}
+
+// EncodeGRPCNormalizeResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCNormalizeResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(normalizeResponse)
+	return &proto.NormalizeResponse{V: resp.V, Normalized: resp.Normalized, Err: resp.Err}, nil
+}
+
+// EncodeGRPCSlugifyResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCSlugifyResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(slugifyResponse)
+	return &proto.SlugifyResponse{V: resp.V, Err: resp.Err}, nil
+}
+
+// EncodeGRPCSimilarityResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCSimilarityResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(similarityResponse)
+	return &proto.SimilarityResponse{Distance: resp.Distance, Score: resp.Score, Exceeded: resp.Exceeded, Err: resp.Err}, nil
+}
+
+// EncodeGRPCMatchResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCMatchResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(matchResponse)
+	return &proto.MatchResponse{V: resp.V, Err: resp.Err}, nil
+}
+
+// EncodeGRPCFindAllResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCFindAllResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(findAllResponse)
+	var matches []*proto.RegexMatch
+	for _, m := range resp.Matches {
+		matches = append(matches, regexMatchToProto(m))
+	}
+	return &proto.FindAllResponse{Matches: matches, Truncated: resp.Truncated, Err: resp.Err}, nil
+}
+
+// EncodeGRPCReplaceAllResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCReplaceAllResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(replaceAllResponse)
+	return &proto.ReplaceAllResponse{V: resp.V, Replaced: int64(resp.Replaced), Truncated: resp.Truncated, Err: resp.Err}, nil
+}
+
+// EncodeGRPCWrapResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCWrapResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(wrapResponse)
+	return &proto.WrapResponse{V: resp.V, Err: resp.Err}, nil
+}
+
+// EncodeGRPCTruncateResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCTruncateResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(truncateResponse)
+	return &proto.TruncateResponse{V: resp.V, Truncated: resp.Truncated, Err: resp.Err}, nil
+}
+
+// EncodeGRPCVersionResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply.
+// Useful in a server.
+func EncodeGRPCVersionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(versionResponse)
+	return &proto.VersionResponse{Version: int64(resp.Version), MinVersion: int64(resp.MinVersion)}, nil
 }
 
 // EncodeGRPCTitleCaseRequest is a transport/grpc.EncodeRequestFunc that converts a
@@ -146,7 +635,7 @@ func EncodeGRPCCountResponse(_ context.Context, response interface{}) (interface
 // Useful in a client.
 func EncodeGRPCTitleCaseRequest(_ context.Context, request interface{}) (interface{}, error) {
 	req := request.(titleCaseRequest)
-	return &proto.TitleCaseRequest{S: req.S}, nil
+	return &proto.TitleCaseRequest{S: req.S, Language: req.Language, Style: req.Style}, nil
 }
 
 // EncodeGRPCRemoveWhitespaceRequest is a transport/grpc.EncodeRequestFunc that converts a
@@ -154,7 +643,7 @@ func EncodeGRPCTitleCaseRequest(_ context.Context, request interface{}) (interfa
 // Useful in a client.
 func EncodeGRPCRemoveWhitespaceRequest(_ context.Context, request interface{}) (interface{}, error) {
 	req := request.(removeWhitespaceRequest)
-	return &proto.RemoveWhitespaceRequest{S: req.S}, nil
+	return &proto.RemoveWhitespaceRequest{S: req.S, Mode: req.Mode, Class: req.Class}, nil
 }
 
 // EncodeGRPCCountRequest is a transport/grpc.EncodeRequestFunc that converts a
@@ -162,5 +651,148 @@ func EncodeGRPCRemoveWhitespaceRequest(_ context.Context, request interface{}) (
 // Useful in a client.
 func EncodeGRPCCountRequest(_ context.Context, request interface{}) (interface{}, error) {
 	req := request.(countRequest)
-	return &proto.CountRequest{S: req.S}, nil
+	return &proto.CountRequest{S: req.S, Unit: req.Unit}, nil
+}
+
+// EncodeGRPCConvertCaseRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCConvertCaseRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(convertCaseRequest)
+	return &proto.ConvertCaseRequest{S: req.S, Style: req.Style}, nil
+}
+
+// EncodeGRPCStatsRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCStatsRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(statsRequest)
+	return &proto.StatsRequest{S: req.S}, nil
+}
+
+// EncodeGRPCNormalizeRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCNormalizeRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(normalizeRequest)
+	return &proto.NormalizeRequest{S: req.S, Form: req.Form, Fold: req.Fold}, nil
+}
+
+// EncodeGRPCSlugifyRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCSlugifyRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(slugifyRequest)
+	return &proto.SlugifyRequest{
+		S:         req.S,
+		Separator: req.Separator,
+		MaxLength: int64(req.MaxLength),
+		StopWords: req.StopWords,
+		Existing:  req.Existing,
+	}, nil
+}
+
+// EncodeGRPCSimilarityRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCSimilarityRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(similarityRequest)
+	return &proto.SimilarityRequest{
+		A:           req.A,
+		B:           req.B,
+		Algorithm:   req.Algorithm,
+		MaxDistance: req.MaxDistance,
+		Unit:        req.Unit,
+	}, nil
+}
+
+// EncodeGRPCMatchRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCMatchRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(matchRequest)
+	return &proto.MatchRequest{S: req.S, Pattern: req.Pattern}, nil
+}
+
+// EncodeGRPCFindAllRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCFindAllRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(findAllRequest)
+	return &proto.FindAllRequest{S: req.S, Pattern: req.Pattern, Limit: int64(req.Limit)}, nil
+}
+
+// EncodeGRPCReplaceAllRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCReplaceAllRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(replaceAllRequest)
+	return &proto.ReplaceAllRequest{S: req.S, Pattern: req.Pattern, Template: req.Template, Limit: int64(req.Limit)}, nil
+}
+
+// EncodeGRPCWrapRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCWrapRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(wrapRequest)
+	return &proto.WrapRequest{
+		S:         req.S,
+		Width:     int64(req.Width),
+		LongWords: req.LongWords,
+		Align:     req.Align,
+		Indent:    int64(req.Indent),
+		Hanging:   int64(req.Hanging),
+	}, nil
+}
+
+// EncodeGRPCTruncateRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCTruncateRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(truncateRequest)
+	return &proto.TruncateRequest{
+		S:            req.S,
+		Limit:        int64(req.Limit),
+		Unit:         req.Unit,
+		WordBoundary: req.WordBoundary,
+		Ellipsis:     req.Ellipsis,
+	}, nil
+}
+
+// EncodeGRPCVersionRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain request to a gRPC request.
+// Useful in a client.
+func EncodeGRPCVersionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	return &proto.VersionRequest{}, nil
+}
+
+// DecodeGRPCError is an endpoint.Middleware returning the errors of
+// patterns answered by the server as a PatternError, like the HTTP client.
+// Useful in a client.
+func DecodeGRPCError(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := next(ctx, request)
+		if st, ok := status.FromError(err); ok && err != nil {
+			if perr, ok := patternErrorFromStatus(st); ok {
+				return nil, perr
+			}
+		}
+		return response, err
+	}
+}
+
+func regexMatchToProto(m RegexMatch) *proto.RegexMatch {
+	pm := &proto.RegexMatch{V: m.V, Start: int64(m.Start), End: int64(m.End)}
+	for _, g := range m.Groups {
+		pm.Groups = append(pm.Groups, &proto.RegexGroup{Name: g.Name, V: g.V, Start: int64(g.Start), End: int64(g.End)})
+	}
+	return pm
+}
+
+func regexMatchFromProto(pm *proto.RegexMatch) RegexMatch {
+	m := RegexMatch{V: pm.V, Start: int(pm.Start), End: int(pm.End)}
+	for _, g := range pm.Groups {
+		m.Groups = append(m.Groups, RegexGroup{Name: g.Name, V: g.V, Start: int(g.Start), End: int(g.End)})
+	}
+	return m
 }
@@ -11,17 +11,18 @@ import (
 	"bytes"
 	"errors"
 	"io/ioutil"
+	"strings"
 
 	"github.com/go-kit/kit/log"
 	httptransport "github.com/go-kit/kit/transport/http"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // MakeHTTPHandler returns a handler that makes a set of endpoints available
-// on predefined paths.
+// on predefined paths. Every response carries the API versions served.
 func MakeHTTPHandler(endpoints Endpoints, logger log.Logger) http.Handler {
 	options := []httptransport.ServerOption{
 		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerBefore(HTTPToIdentity, HTTPToVersion),
 	}
 	m := http.NewServeMux()
 	m.Handle("/tc",
@@ -45,8 +46,84 @@ func MakeHTTPHandler(endpoints Endpoints, logger log.Logger) http.Handler {
 			EncodeHTTPResponse,
 			options...,
 		))
-	m.Handle("/metrics", promhttp.Handler())
-	return m
+	m.Handle("/cc",
+		httptransport.NewServer(
+			endpoints.ConvertCaseEndpoint,
+			DecodeHTTPConvertCaseRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/stats",
+		httptransport.NewServer(
+			endpoints.StatsEndpoint,
+			DecodeHTTPStatsRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/norm",
+		httptransport.NewServer(
+			endpoints.NormalizeEndpoint,
+			DecodeHTTPNormalizeRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/slug",
+		httptransport.NewServer(
+			endpoints.SlugifyEndpoint,
+			DecodeHTTPSlugifyRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/sim",
+		httptransport.NewServer(
+			endpoints.SimilarityEndpoint,
+			DecodeHTTPSimilarityRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/match",
+		httptransport.NewServer(
+			endpoints.MatchEndpoint,
+			DecodeHTTPMatchRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/findall",
+		httptransport.NewServer(
+			endpoints.FindAllEndpoint,
+			DecodeHTTPFindAllRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/replace",
+		httptransport.NewServer(
+			endpoints.ReplaceAllEndpoint,
+			DecodeHTTPReplaceAllRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/wrap",
+		httptransport.NewServer(
+			endpoints.WrapEndpoint,
+			DecodeHTTPWrapRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/trunc",
+		httptransport.NewServer(
+			endpoints.TruncateEndpoint,
+			DecodeHTTPTruncateRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	m.Handle("/version",
+		httptransport.NewServer(
+			endpoints.VersionEndpoint,
+			DecodeHTTPVersionRequest,
+			EncodeHTTPResponse,
+			options...,
+		))
+	return versionHeaders(m)
 }
 
 // DecodeHTTPTitleCaseRequest is a transport/http.DecodeRequestFunc that decodes a
@@ -82,6 +159,123 @@ func DecodeHTTPCountRequest(_ context.Context, r *http.Request) (interface{}, er
 	return request, nil
 }
 
+// DecodeHTTPConvertCaseRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPConvertCaseRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request convertCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPStatsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPStatsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request statsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPNormalizeRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPNormalizeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request normalizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPSlugifyRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPSlugifyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request slugifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPSimilarityRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPSimilarityRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request similarityRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPMatchRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPMatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request matchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPFindAllRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPFindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request findAllRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPReplaceAllRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPReplaceAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request replaceAllRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPWrapRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPWrapRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request wrapRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPTruncateRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body.
+// Useful in a server.
+func DecodeHTTPTruncateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request truncateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// DecodeHTTPVersionRequest is a transport/http.DecodeRequestFunc for
+// requests of the versions served, which carry no body.
+// Useful in a server.
+func DecodeHTTPVersionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return versionRequest{}, nil
+}
+
 // DecodeHTTPTitleCaseResponse is a transport/http.DecodeResponseFunc that decodes a
 // JSON-encoded response from the HTTP response body. For non-200 status code response
 // an error message decoding attempt is made on response body.
@@ -121,6 +315,156 @@ func DecodeHTTPCountResponse(_ context.Context, r *http.Response) (interface{},
 	return resp, err
 }
 
+// DecodeHTTPConvertCaseResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPConvertCaseResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp convertCaseResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPStatsResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPStatsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp statsResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPNormalizeResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPNormalizeResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp normalizeResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPSlugifyResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPSlugifyResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp slugifyResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPSimilarityResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPSimilarityResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp similarityResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPMatchResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPMatchResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp matchResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPFindAllResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPFindAllResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp findAllResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPReplaceAllResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPReplaceAllResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp replaceAllResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPWrapResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPWrapResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp wrapResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPTruncateResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPTruncateResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp truncateResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// DecodeHTTPVersionResponse is a transport/http.DecodeResponseFunc that decodes a
+// JSON-encoded response from the HTTP response body. For non-200 status code response
+// an error message decoding attempt is made on response body.
+// Useful in a client.
+func DecodeHTTPVersionResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errorDecoder(r)
+	}
+	var resp versionResponse
+	err := json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
+}
+
+// EncodeHTTPVersionRequest is a transport/http.EncodeRequestFunc for
+// requests of the versions served, which carry no body.
+// Useful in a client.
+func EncodeHTTPVersionRequest(context.Context, *http.Request, interface{}) error {
+	return nil
+}
+
 // EncodeHTTPRequest is a transport/http.EncodeRequestFunc that JSON-encodes any
 // request to the request body.
 // Useful in a client.
@@ -140,14 +484,34 @@ func EncodeHTTPResponse(_ context.Context, w http.ResponseWriter, response inter
 	return json.NewEncoder(w).Encode(response)
 }
 
+// errorDecoder returns the error in the body of a failed response, either
+// JSON-encoded or the plain text written by the default error encoder.
+// Errors of patterns are returned as a PatternError.
 func errorDecoder(r *http.Response) error {
-	var w errorWrapper
-	if err := json.NewDecoder(r.Body).Decode(&w); err != nil {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
 		return err
 	}
-	return errors.New(w.Error)
+	var w errorWrapper
+	if err := json.Unmarshal(body, &w); err == nil && w.Error != "" {
+		if w.Pattern != nil {
+			perr := PatternError{Pattern: *w.Pattern, Offset: -1, Reason: w.Reason}
+			if w.Offset != nil {
+				perr.Offset = *w.Offset
+			}
+			return perr
+		}
+		return errors.New(w.Error)
+	}
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return errors.New(msg)
+	}
+	return errors.New(r.Status)
 }
 
 type errorWrapper struct {
-	Error string `json:"error"`
+	Error   string  `json:"error"`
+	Pattern *string `json:"pattern,omitempty"`
+	Offset  *int    `json:"offset,omitempty"`
+	Reason  string  `json:"reason,omitempty"`
 }
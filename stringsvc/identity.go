@@ -0,0 +1,102 @@
+package stringsvc
+
+// Identity of the caller as seen by the transport, carried in the
+// request context from the transport to the endpoints.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Identity describes who made a request and from where. Caller, Tenant
+// and ForwardedFor are reported by the client: the caller is only
+// authenticated by the Tenancy in authenticate mode, against the basic
+// auth password, and any client can claim any caller or tenant otherwise.
+// Remote is the address of the peer of the connection.
+type Identity struct {
+	Caller       string
+	Tenant       string
+	Remote       string
+	ForwardedFor string
+	Transport    string
+
+	password string // basic auth password of Caller, if given
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	if latest, ok := ctx.Value(latestIdentityKey{}).(*Identity); ok {
+		*latest = id
+	}
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+type latestIdentityKey struct{}
+
+// trackIdentity returns a copy of ctx along with the Identity it carries,
+// which is kept up to date with the Identity that middlewares further in
+// store with WithIdentity, such as the tenant resolved from the caller.
+func trackIdentity(ctx context.Context) (context.Context, *Identity) {
+	id, _ := IdentityFromContext(ctx)
+	latest := &id
+	return context.WithValue(ctx, latestIdentityKey{}, latest), latest
+}
+
+// IdentityFromContext returns the identity stored in ctx by the transport.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// callerHeader names the caller when basic authentication is not used.
+const callerHeader = "X-Caller"
+
+// TenantHeader names the tenant the call is made for. gRPC calls carry
+// it in lower case metadata.
+const TenantHeader = "X-Tenant-ID"
+
+// HTTPToIdentity is a transport/http.RequestFunc that stores the Identity
+// of the caller in the context. The caller is the basic auth user name,
+// whose password is checked by the Tenancy in authenticate mode only, or
+// the X-Caller header, the tenant is the X-Tenant-ID header and the remote
+// address that of the connection, with the X-Forwarded-For header kept
+// apart as ForwardedFor.
+func HTTPToIdentity(ctx context.Context, r *http.Request) context.Context {
+	id := Identity{Tenant: r.Header.Get(TenantHeader), Remote: r.RemoteAddr, Transport: "http"}
+	if user, password, ok := r.BasicAuth(); ok {
+		id.Caller, id.password = user, password
+	} else {
+		id.Caller = r.Header.Get(callerHeader)
+	}
+	id.ForwardedFor = r.Header.Get("X-Forwarded-For")
+	return WithIdentity(ctx, id)
+}
+
+// GRPCToIdentity is a transport/grpc.ServerRequestFunc that stores the
+// Identity of the caller in the context. The caller is taken from basic
+// credentials in the authorization metadata, like HTTPToIdentity, or the
+// x-caller metadata, the tenant from the x-tenant-id metadata and the
+// remote address from the connection peer.
+func GRPCToIdentity(ctx context.Context, md metadata.MD) context.Context {
+	id := Identity{Transport: "grpc"}
+	if v := md.Get("authorization"); len(v) > 0 {
+		r := http.Request{Header: http.Header{"Authorization": v[:1]}}
+		id.Caller, id.password, _ = r.BasicAuth()
+	}
+	if v := md.Get(strings.ToLower(callerHeader)); len(v) > 0 && id.Caller == "" {
+		id.Caller = v[0]
+	}
+	if v := md.Get(strings.ToLower(TenantHeader)); len(v) > 0 {
+		id.Tenant = v[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		id.Remote = p.Addr.String()
+	}
+	return WithIdentity(ctx, id)
+}
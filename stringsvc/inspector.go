@@ -0,0 +1,40 @@
+package stringsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/afrometal/go-kit-svc/inspector"
+	"github.com/go-kit/kit/endpoint"
+)
+
+// NewInspectorMiddleware returns a MethodMiddleware that records every call
+// in the inspector with its timing, input, error and caller Identity.
+func NewInspectorMiddleware(in *inspector.Inspector) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				defer func(begin time.Time) {
+					id, _ := IdentityFromContext(ctx)
+					c := inspector.Call{
+						Method:    method,
+						Start:     begin,
+						Took:      time.Since(begin),
+						Caller:    id.Caller,
+						Remote:    id.Remote,
+						Transport: id.Transport,
+					}
+					if r, ok := request.(inputRequest); ok {
+						c.Input = r.input()
+					}
+					if failed := callError(response, err); failed != nil {
+						c.Err = failed.Error()
+					}
+					in.Record(c)
+				}(time.Now())
+
+				return next(ctx, request)
+			}
+		}
+	}
+}
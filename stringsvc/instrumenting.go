@@ -16,82 +16,200 @@ type instrumentingMiddleware struct {
 	requestLatency metrics.Histogram
 	countResult    metrics.Histogram
 	charsRemoved   metrics.Histogram
+	observers      []RequestObserver
 	next           StringService
 }
 
+// RequestObserver is notified of the outcome of every request
+// measured by the instrumenting middleware. Requests are failed only by
+// errors of the service; those caused by the request, such as ErrEmptyString
+// or a PatternError, are not failures of the service.
+type RequestObserver interface {
+	Observe(method string, failed bool, took time.Duration)
+}
+
 // NewInstrumentingMiddleware returns StringService middleware that instruments
 // the number of requests received, total duration of requests, number of chars removed
-// and result of each count method.
-func NewInstrumentingMiddleware(svc StringService) StringService {
+// and result of each count method. Requests are labeled with their tenant if it is
+// configured, see NewTenantMiddleware, and "other" if not. Each request is also
+// reported to observers. The metrics are registered in reg.
+func NewInstrumentingMiddleware(svc StringService, reg stdprometheus.Registerer, observers ...RequestObserver) StringService {
 
-	fieldKeys := []string{"method", "error"}
-	requestCount := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	fieldKeys := []string{"method", "tenant", "error"}
+	requestCount := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
 		Namespace: "my_group",
 		Subsystem: "string_service",
 		Name:      "request_count",
 		Help:      "Number of requests received.",
 	}, fieldKeys)
-	requestLatency := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+	requestLatency := stdprometheus.NewSummaryVec(stdprometheus.SummaryOpts{
 		Namespace: "my_group",
 		Subsystem: "string_service",
 		Name:      "request_latency_microseconds",
 		Help:      "Total duration of requests in microseconds.",
 	}, fieldKeys)
-	countResult := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+	countResult := stdprometheus.NewSummaryVec(stdprometheus.SummaryOpts{
 		Namespace: "my_group",
 		Subsystem: "string_service",
 		Name:      "count_result",
 		Help:      "The result of each count method.",
 	}, []string{}) // no fields here
-	charsRemoved := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+	charsRemoved := stdprometheus.NewSummaryVec(stdprometheus.SummaryOpts{
 		Namespace: "my_group",
 		Subsystem: "string_service",
 		Name:      "chars_removed",
 		Help:      "The number of chars removed by whitespace remover.",
 	}, []string{}) // no fields here
 
+	reg.MustRegister(requestCount, requestLatency, countResult, charsRemoved)
+
 	return instrumentingMiddleware{
-		requestCount,
-		requestLatency,
-		countResult,
-		charsRemoved,
+		kitprometheus.NewCounter(requestCount),
+		kitprometheus.NewSummary(requestLatency),
+		kitprometheus.NewSummary(countResult),
+		kitprometheus.NewSummary(charsRemoved),
+		observers,
 		svc,
 	}
 }
 
-func (mw instrumentingMiddleware) TitleCase(ctx context.Context, s string) (output string, err error) {
+func (mw instrumentingMiddleware) observe(ctx context.Context, method string, err error, begin time.Time) {
+	took := time.Since(begin)
+	lvs := []string{"method", method, "tenant", tenantLabel(ctx), "error", fmt.Sprint(err != nil)}
+	mw.requestCount.With(lvs...).Add(1)
+	mw.requestLatency.With(lvs...).Observe(took.Seconds())
+	for _, o := range mw.observers {
+		o.Observe(method, err != nil && !clientError(err), took)
+	}
+}
+
+func (mw instrumentingMiddleware) TitleCase(ctx context.Context, s string, opts TitleCaseOptions) (output string, err error) {
 	defer func(begin time.Time) {
-		lvs := []string{"method", "title_case", "error", fmt.Sprint(err != nil)}
-		mw.requestCount.With(lvs...).Add(1)
-		mw.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.observe(ctx, "title_case", err, begin)
 	}(time.Now())
 
-	output, err = mw.next.TitleCase(ctx, s)
+	output, err = mw.next.TitleCase(ctx, s, opts)
 	return
 }
 
-func (mw instrumentingMiddleware) RemoveWhitespace(ctx context.Context, s string) (output string, err error) {
-	var removed int
+func (mw instrumentingMiddleware) RemoveWhitespace(ctx context.Context, s string, opts WhitespaceOptions) (output string, removed []int, err error) {
 	defer func(begin time.Time) {
-		lvs := []string{"method", "remove_whitespace", "error", fmt.Sprint(err != nil)}
-		mw.requestCount.With(lvs...).Add(1)
-		mw.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
-		mw.charsRemoved.Observe(float64(removed))
+		mw.observe(ctx, "remove_whitespace", err, begin)
+		mw.charsRemoved.Observe(float64(len(removed)))
 	}(time.Now())
 
-	output, err = mw.next.RemoveWhitespace(ctx, s)
-	removed = len(s) - len(output)
+	output, removed, err = mw.next.RemoveWhitespace(ctx, s, opts)
 	return
 }
 
 func (mw instrumentingMiddleware) Count(ctx context.Context, s string) (n int) {
 	defer func(begin time.Time) {
-		lvs := []string{"method", "count", "error", "false"}
-		mw.requestCount.With(lvs...).Add(1)
-		mw.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.observe(ctx, "count", nil, begin)
 		mw.countResult.Observe(float64(n))
 	}(time.Now())
 
 	n = mw.next.Count(ctx, s)
 	return
 }
+
+func (mw instrumentingMiddleware) CountUnit(ctx context.Context, s, unit string) (n int, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "count", err, begin)
+		if err == nil {
+			mw.countResult.Observe(float64(n))
+		}
+	}(time.Now())
+
+	n, err = mw.next.CountUnit(ctx, s, unit)
+	return
+}
+
+func (mw instrumentingMiddleware) ConvertCase(ctx context.Context, s, style string) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "convert_case", err, begin)
+	}(time.Now())
+
+	output, err = mw.next.ConvertCase(ctx, s, style)
+	return
+}
+
+func (mw instrumentingMiddleware) Stats(ctx context.Context, s string) (stats TextStats, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "stats", err, begin)
+	}(time.Now())
+
+	stats, err = mw.next.Stats(ctx, s)
+	return
+}
+
+func (mw instrumentingMiddleware) Normalize(ctx context.Context, s string, opts NormalizeOptions) (output string, normalized bool, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "normalize", err, begin)
+	}(time.Now())
+
+	output, normalized, err = mw.next.Normalize(ctx, s, opts)
+	return
+}
+
+func (mw instrumentingMiddleware) Slugify(ctx context.Context, s string, opts SlugOptions) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "slugify", err, begin)
+	}(time.Now())
+
+	output, err = mw.next.Slugify(ctx, s, opts)
+	return
+}
+
+func (mw instrumentingMiddleware) Similarity(ctx context.Context, a, b string, opts SimilarityOptions) (res SimilarityResult, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "similarity", err, begin)
+	}(time.Now())
+
+	res, err = mw.next.Similarity(ctx, a, b, opts)
+	return
+}
+
+func (mw instrumentingMiddleware) Match(ctx context.Context, s, pattern string) (output bool, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "match", err, begin)
+	}(time.Now())
+
+	output, err = mw.next.Match(ctx, s, pattern)
+	return
+}
+
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, s, pattern string, limit int) (res FindAllResult, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "find_all", err, begin)
+	}(time.Now())
+
+	res, err = mw.next.FindAll(ctx, s, pattern, limit)
+	return
+}
+
+func (mw instrumentingMiddleware) ReplaceAll(ctx context.Context, s, pattern, template string, limit int) (res ReplaceAllResult, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "replace_all", err, begin)
+	}(time.Now())
+
+	res, err = mw.next.ReplaceAll(ctx, s, pattern, template, limit)
+	return
+}
+
+func (mw instrumentingMiddleware) Wrap(ctx context.Context, s string, opts WrapOptions) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "wrap", err, begin)
+	}(time.Now())
+
+	output, err = mw.next.Wrap(ctx, s, opts)
+	return
+}
+
+func (mw instrumentingMiddleware) Truncate(ctx context.Context, s string, opts TruncateOptions) (output string, truncated bool, err error) {
+	defer func(begin time.Time) {
+		mw.observe(ctx, "truncate", err, begin)
+	}(time.Now())
+
+	output, truncated, err = mw.next.Truncate(ctx, s, opts)
+	return
+}
@@ -20,33 +20,38 @@ func NewLoggingMiddleware(svc StringService, logger log.Logger) StringService {
 	return loggingMiddleware{logger, svc}
 }
 
-func (mw loggingMiddleware) TitleCase(ctx context.Context, s string) (output string, err error) {
+func (mw loggingMiddleware) TitleCase(ctx context.Context, s string, opts TitleCaseOptions) (output string, err error) {
 	defer func(begin time.Time) {
 		mw.logger.Log(
 			"method", "title_case",
 			"input", s,
+			"language", opts.Language,
+			"style", opts.Style,
 			"output", output,
 			"err", err,
 			"took", time.Since(begin),
 		)
 	}(time.Now())
 
-	output, err = mw.next.TitleCase(ctx, s)
+	output, err = mw.next.TitleCase(ctx, s, opts)
 	return
 }
 
-func (mw loggingMiddleware) RemoveWhitespace(ctx context.Context, s string) (output string, err error) {
+func (mw loggingMiddleware) RemoveWhitespace(ctx context.Context, s string, opts WhitespaceOptions) (output string, removed []int, err error) {
 	defer func(begin time.Time) {
 		mw.logger.Log(
 			"method", "remove_whitespace",
 			"input", s,
+			"mode", opts.Mode,
+			"class", opts.Class,
 			"output", output,
+			"removed", len(removed),
 			"err", err,
 			"took", time.Since(begin),
 		)
 	}(time.Now())
 
-	output, err = mw.next.RemoveWhitespace(ctx, s)
+	output, removed, err = mw.next.RemoveWhitespace(ctx, s, opts)
 	return
 }
 
@@ -63,3 +68,208 @@ func (mw loggingMiddleware) Count(ctx context.Context, s string) (n int) {
 	n = mw.next.Count(ctx, s)
 	return
 }
+
+func (mw loggingMiddleware) CountUnit(ctx context.Context, s, unit string) (n int, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "count",
+			"input", s,
+			"unit", unit,
+			"n", n,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	n, err = mw.next.CountUnit(ctx, s, unit)
+	return
+}
+
+func (mw loggingMiddleware) ConvertCase(ctx context.Context, s, style string) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "convert_case",
+			"input", s,
+			"style", style,
+			"output", output,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, err = mw.next.ConvertCase(ctx, s, style)
+	return
+}
+
+func (mw loggingMiddleware) Stats(ctx context.Context, s string) (stats TextStats, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "stats",
+			"input", s,
+			"bytes", stats.Bytes,
+			"runes", stats.Runes,
+			"graphemes", stats.Graphemes,
+			"words", stats.Words,
+			"lines", stats.Lines,
+			"sentences", stats.Sentences,
+			"width", stats.Width,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	stats, err = mw.next.Stats(ctx, s)
+	return
+}
+
+func (mw loggingMiddleware) Normalize(ctx context.Context, s string, opts NormalizeOptions) (output string, normalized bool, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "normalize",
+			"input", s,
+			"form", opts.Form,
+			"fold", opts.Fold,
+			"output", output,
+			"normalized", normalized,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, normalized, err = mw.next.Normalize(ctx, s, opts)
+	return
+}
+
+func (mw loggingMiddleware) Slugify(ctx context.Context, s string, opts SlugOptions) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "slugify",
+			"input", s,
+			"separator", opts.Separator,
+			"max_length", opts.MaxLength,
+			"stop_words", len(opts.StopWords),
+			"existing", len(opts.Existing),
+			"output", output,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, err = mw.next.Slugify(ctx, s, opts)
+	return
+}
+
+func (mw loggingMiddleware) Similarity(ctx context.Context, a, b string, opts SimilarityOptions) (res SimilarityResult, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "similarity",
+			"a", a,
+			"b", b,
+			"algorithm", opts.Algorithm,
+			"max_distance", opts.MaxDistance,
+			"unit", opts.Unit,
+			"distance", res.Distance,
+			"score", res.Score,
+			"exceeded", res.Exceeded,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	res, err = mw.next.Similarity(ctx, a, b, opts)
+	return
+}
+
+func (mw loggingMiddleware) Match(ctx context.Context, s, pattern string) (output bool, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "match",
+			"input", s,
+			"pattern", pattern,
+			"output", output,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, err = mw.next.Match(ctx, s, pattern)
+	return
+}
+
+func (mw loggingMiddleware) FindAll(ctx context.Context, s, pattern string, limit int) (res FindAllResult, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "find_all",
+			"input", s,
+			"pattern", pattern,
+			"limit", limit,
+			"matches", len(res.Matches),
+			"truncated", res.Truncated,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	res, err = mw.next.FindAll(ctx, s, pattern, limit)
+	return
+}
+
+func (mw loggingMiddleware) ReplaceAll(ctx context.Context, s, pattern, template string, limit int) (res ReplaceAllResult, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "replace_all",
+			"input", s,
+			"pattern", pattern,
+			"template", template,
+			"limit", limit,
+			"output", res.V,
+			"replaced", res.Replaced,
+			"truncated", res.Truncated,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	res, err = mw.next.ReplaceAll(ctx, s, pattern, template, limit)
+	return
+}
+
+func (mw loggingMiddleware) Wrap(ctx context.Context, s string, opts WrapOptions) (output string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "wrap",
+			"input", s,
+			"width", opts.Width,
+			"long_words", opts.LongWords,
+			"align", opts.Align,
+			"indent", opts.Indent,
+			"hanging", opts.Hanging,
+			"output", output,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, err = mw.next.Wrap(ctx, s, opts)
+	return
+}
+
+func (mw loggingMiddleware) Truncate(ctx context.Context, s string, opts TruncateOptions) (output string, truncated bool, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "truncate",
+			"input", s,
+			"limit", opts.Limit,
+			"unit", opts.Unit,
+			"word_boundary", opts.WordBoundary,
+			"ellipsis", opts.Ellipsis,
+			"output", output,
+			"truncated", truncated,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	output, truncated, err = mw.next.Truncate(ctx, s, opts)
+	return
+}
@@ -0,0 +1,62 @@
+package stringsvc
+
+// Unicode normalization forms and case folding of Normalize.
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization forms of Normalize, matched case-insensitively.
+const (
+	FormNFC  = "nfc"  // canonical composition
+	FormNFD  = "nfd"  // canonical decomposition
+	FormNFKC = "nfkc" // compatibility composition
+	FormNFKD = "nfkd" // compatibility decomposition
+)
+
+// NormalizationForms lists the normalization forms of Normalize.
+var NormalizationForms = []string{FormNFC, FormNFD, FormNFKC, FormNFKD}
+
+// NormalizeOptions of Normalize. The zero value normalizes to NFC.
+type NormalizeOptions struct {
+	// Form is one of NormalizationForms, FormNFC if empty.
+	Form string
+	// Fold applies full Unicode case folding, so that strings differing
+	// only in case, such as "Straße" and "STRASSE", normalize the same.
+	Fold bool
+}
+
+// normForms maps the names of NormalizationForms to their forms.
+var normForms = map[string]norm.Form{
+	FormNFC:  norm.NFC,
+	FormNFD:  norm.NFD,
+	FormNFKC: norm.NFKC,
+	FormNFKD: norm.NFKD,
+}
+
+// normalize returns s normalized as in opts and whether s already was.
+// Without case folding the check is the quick one of the form, which
+// leaves s as is if it passes.
+func normalize(s string, opts NormalizeOptions) (string, bool, error) {
+	name := strings.ToLower(opts.Form)
+	if name == "" {
+		name = FormNFC
+	}
+	form, ok := normForms[name]
+	if !ok {
+		return "", false, invalidInput("unknown normalization form %q, want one of %s", opts.Form, strings.Join(NormalizationForms, ", "))
+	}
+	if !opts.Fold {
+		if form.IsNormalString(s) {
+			return s, true, nil
+		}
+		return form.String(s), false, nil
+	}
+	// Decomposed text is folded, as in the case insensitive matching of
+	// the Unicode Standard, and the folded text normalized again.
+	v := form.String(cases.Fold().String(norm.NFD.String(s)))
+	return v, v == s, nil
+}
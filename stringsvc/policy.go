@@ -0,0 +1,126 @@
+package stringsvc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/endpoint"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limit is the sustained rate of calls per second a method accepts,
+// with bursts of up to Burst calls.
+type Limit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// PolicySettings declares how methods are rate limited.
+// Methods without a limit are not limited.
+type PolicySettings struct {
+	Limits map[string]Limit `yaml:"limits"`
+}
+
+// Validate checks that the settings only name known methods
+// and that every limit allows calls.
+func (s PolicySettings) Validate() error {
+	for m, l := range s.Limits {
+		switch {
+		case !IsMethod(m):
+			return fmt.Errorf("limits: unknown method %q", m)
+		case l.Rate <= 0:
+			return fmt.Errorf("limits.%s: rate must be positive, got %v", m, l.Rate)
+		case l.Burst < 1:
+			return fmt.Errorf("limits.%s: burst must be at least 1, got %d", m, l.Burst)
+		}
+	}
+	return nil
+}
+
+// Policy decides whether calls are within the limits. Its settings can be
+// replaced while the service runs; every call is decided by a single
+// version of them.
+type Policy struct {
+	current atomic.Value // *policy
+}
+
+type policy struct {
+	settings PolicySettings
+	limiters map[string]*rate.Limiter
+}
+
+// NewPolicy returns a Policy with the validated settings.
+func NewPolicy(s PolicySettings) (*Policy, error) {
+	p := &Policy{}
+	p.current.Store(&policy{})
+	if err := p.Update(s); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update validates and applies the settings. Limiters of methods whose
+// limit did not change are kept along with the calls they accounted for.
+func (p *Policy) Update(s PolicySettings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	prev := p.current.Load().(*policy)
+	next := &policy{
+		settings: s,
+		limiters: map[string]*rate.Limiter{},
+	}
+	for m, l := range s.Limits {
+		if lim, ok := prev.limiters[m]; ok && prev.settings.Limits[m] == l {
+			next.limiters[m] = lim
+			continue
+		}
+		next.limiters[m] = rate.NewLimiter(rate.Limit(l.Rate), l.Burst)
+	}
+	p.current.Store(next)
+	return nil
+}
+
+// NewPolicyMiddleware returns a MethodMiddleware that rejects calls
+// exceeding the limit of their method with ErrRateLimited.
+func NewPolicyMiddleware(p *Policy) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				if !p.allow(method) {
+					return nil, ErrRateLimited
+				}
+				return next(ctx, request)
+			}
+		}
+	}
+}
+
+// allow reports whether a call of the method is within its limit.
+func (p *Policy) allow(method string) bool {
+	cur := p.current.Load().(*policy)
+	lim, ok := cur.limiters[method]
+	return !ok || lim.Allow()
+}
+
+// ErrRateLimited is returned for calls rejected by the Policy.
+var ErrRateLimited = policyError{"rate limit exceeded", http.StatusTooManyRequests, codes.ResourceExhausted}
+
+// policyError is answered with its status code by both transports.
+type policyError struct {
+	msg  string
+	code int
+	grpc codes.Code
+}
+
+func (e policyError) Error() string { return e.msg }
+
+// StatusCode implements transport/http.StatusCoder.
+func (e policyError) StatusCode() int { return e.code }
+
+// GRPCStatus lets the gRPC server answer with the status code of the error.
+func (e policyError) GRPCStatus() *status.Status { return status.New(e.grpc, e.msg) }
@@ -14,6 +14,30 @@ It has these top-level messages:
 	RemoveWhitespaceResponse
 	CountRequest
 	CountResponse
+	ConvertCaseRequest
+	ConvertCaseResponse
+	StatsRequest
+	StatsResponse
+	NormalizeRequest
+	NormalizeResponse
+	SlugifyRequest
+	SlugifyResponse
+	SimilarityRequest
+	SimilarityResponse
+	MatchRequest
+	MatchResponse
+	FindAllRequest
+	RegexMatch
+	RegexGroup
+	FindAllResponse
+	ReplaceAllRequest
+	ReplaceAllResponse
+	WrapRequest
+	WrapResponse
+	TruncateRequest
+	TruncateResponse
+	VersionRequest
+	VersionResponse
 */
 package proto
 
@@ -38,7 +62,9 @@ var _ = math.Inf
 const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type TitleCaseRequest struct {
-	S string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	S        string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language" json:"language,omitempty"`
+	Style    string `protobuf:"bytes,3,opt,name=style" json:"style,omitempty"`
 }
 
 func (m *TitleCaseRequest) Reset()                    { *m = TitleCaseRequest{} }
@@ -53,6 +79,20 @@ func (m *TitleCaseRequest) GetS() string {
 	return ""
 }
 
+func (m *TitleCaseRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *TitleCaseRequest) GetStyle() string {
+	if m != nil {
+		return m.Style
+	}
+	return ""
+}
+
 type TitleCaseResponse struct {
 	V   string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
 	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
@@ -78,7 +118,9 @@ func (m *TitleCaseResponse) GetErr() string {
 }
 
 type RemoveWhitespaceRequest struct {
-	S string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	S     string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Mode  string `protobuf:"bytes,2,opt,name=mode" json:"mode,omitempty"`
+	Class string `protobuf:"bytes,3,opt,name=class" json:"class,omitempty"`
 }
 
 func (m *RemoveWhitespaceRequest) Reset()                    { *m = RemoveWhitespaceRequest{} }
@@ -93,9 +135,25 @@ func (m *RemoveWhitespaceRequest) GetS() string {
 	return ""
 }
 
+func (m *RemoveWhitespaceRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *RemoveWhitespaceRequest) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
+}
+
 type RemoveWhitespaceResponse struct {
-	V   string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
-	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+	V         string  `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Err       string  `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+	Removed   int64   `protobuf:"varint,3,opt,name=removed" json:"removed,omitempty"`
+	Positions []int64 `protobuf:"varint,4,rep,packed,name=positions" json:"positions,omitempty"`
 }
 
 func (m *RemoveWhitespaceResponse) Reset()                    { *m = RemoveWhitespaceResponse{} }
@@ -107,44 +165,898 @@ func (m *RemoveWhitespaceResponse) GetV() string {
 	if m != nil {
 		return m.V
 	}
-	return ""
+	return ""
+}
+
+func (m *RemoveWhitespaceResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *RemoveWhitespaceResponse) GetRemoved() int64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *RemoveWhitespaceResponse) GetPositions() []int64 {
+	if m != nil {
+		return m.Positions
+	}
+	return nil
+}
+
+type CountRequest struct {
+	S    string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Unit string `protobuf:"bytes,2,opt,name=unit" json:"unit,omitempty"`
+}
+
+func (m *CountRequest) Reset()                    { *m = CountRequest{} }
+func (m *CountRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CountRequest) ProtoMessage()               {}
+func (*CountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *CountRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *CountRequest) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+type CountResponse struct {
+	V   int64  `protobuf:"varint,1,opt,name=v" json:"v,omitempty"`
+	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *CountResponse) Reset()                    { *m = CountResponse{} }
+func (m *CountResponse) String() string            { return proto1.CompactTextString(m) }
+func (*CountResponse) ProtoMessage()               {}
+func (*CountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CountResponse) GetV() int64 {
+	if m != nil {
+		return m.V
+	}
+	return 0
+}
+
+func (m *CountResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type ConvertCaseRequest struct {
+	S     string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Style string `protobuf:"bytes,2,opt,name=style" json:"style,omitempty"`
+}
+
+func (m *ConvertCaseRequest) Reset()                    { *m = ConvertCaseRequest{} }
+func (m *ConvertCaseRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ConvertCaseRequest) ProtoMessage()               {}
+func (*ConvertCaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ConvertCaseRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *ConvertCaseRequest) GetStyle() string {
+	if m != nil {
+		return m.Style
+	}
+	return ""
+}
+
+type ConvertCaseResponse struct {
+	V   string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *ConvertCaseResponse) Reset()                    { *m = ConvertCaseResponse{} }
+func (m *ConvertCaseResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ConvertCaseResponse) ProtoMessage()               {}
+func (*ConvertCaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *ConvertCaseResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *ConvertCaseResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type StatsRequest struct {
+	S string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+}
+
+func (m *StatsRequest) Reset()                    { *m = StatsRequest{} }
+func (m *StatsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()               {}
+func (*StatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *StatsRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+type StatsResponse struct {
+	Bytes     int64  `protobuf:"varint,1,opt,name=bytes" json:"bytes,omitempty"`
+	Runes     int64  `protobuf:"varint,2,opt,name=runes" json:"runes,omitempty"`
+	Graphemes int64  `protobuf:"varint,3,opt,name=graphemes" json:"graphemes,omitempty"`
+	Words     int64  `protobuf:"varint,4,opt,name=words" json:"words,omitempty"`
+	Lines     int64  `protobuf:"varint,5,opt,name=lines" json:"lines,omitempty"`
+	Sentences int64  `protobuf:"varint,6,opt,name=sentences" json:"sentences,omitempty"`
+	Width     int64  `protobuf:"varint,7,opt,name=width" json:"width,omitempty"`
+	Err       string `protobuf:"bytes,8,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *StatsResponse) Reset()                    { *m = StatsResponse{} }
+func (m *StatsResponse) String() string            { return proto1.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()               {}
+func (*StatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *StatsResponse) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetRunes() int64 {
+	if m != nil {
+		return m.Runes
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetGraphemes() int64 {
+	if m != nil {
+		return m.Graphemes
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetWords() int64 {
+	if m != nil {
+		return m.Words
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetSentences() int64 {
+	if m != nil {
+		return m.Sentences
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetWidth() int64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type NormalizeRequest struct {
+	S    string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Form string `protobuf:"bytes,2,opt,name=form" json:"form,omitempty"`
+	Fold bool   `protobuf:"varint,3,opt,name=fold" json:"fold,omitempty"`
+}
+
+func (m *NormalizeRequest) Reset()                    { *m = NormalizeRequest{} }
+func (m *NormalizeRequest) String() string            { return proto1.CompactTextString(m) }
+func (*NormalizeRequest) ProtoMessage()               {}
+func (*NormalizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *NormalizeRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *NormalizeRequest) GetForm() string {
+	if m != nil {
+		return m.Form
+	}
+	return ""
+}
+
+func (m *NormalizeRequest) GetFold() bool {
+	if m != nil {
+		return m.Fold
+	}
+	return false
+}
+
+type NormalizeResponse struct {
+	V          string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Normalized bool   `protobuf:"varint,2,opt,name=normalized" json:"normalized,omitempty"`
+	Err        string `protobuf:"bytes,3,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *NormalizeResponse) Reset()                    { *m = NormalizeResponse{} }
+func (m *NormalizeResponse) String() string            { return proto1.CompactTextString(m) }
+func (*NormalizeResponse) ProtoMessage()               {}
+func (*NormalizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *NormalizeResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *NormalizeResponse) GetNormalized() bool {
+	if m != nil {
+		return m.Normalized
+	}
+	return false
+}
+
+func (m *NormalizeResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type SlugifyRequest struct {
+	S         string   `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Separator string   `protobuf:"bytes,2,opt,name=separator" json:"separator,omitempty"`
+	MaxLength int64    `protobuf:"varint,3,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	StopWords []string `protobuf:"bytes,4,rep,name=stop_words,json=stopWords" json:"stop_words,omitempty"`
+	Existing  []string `protobuf:"bytes,5,rep,name=existing" json:"existing,omitempty"`
+}
+
+func (m *SlugifyRequest) Reset()                    { *m = SlugifyRequest{} }
+func (m *SlugifyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*SlugifyRequest) ProtoMessage()               {}
+func (*SlugifyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *SlugifyRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *SlugifyRequest) GetSeparator() string {
+	if m != nil {
+		return m.Separator
+	}
+	return ""
+}
+
+func (m *SlugifyRequest) GetMaxLength() int64 {
+	if m != nil {
+		return m.MaxLength
+	}
+	return 0
+}
+
+func (m *SlugifyRequest) GetStopWords() []string {
+	if m != nil {
+		return m.StopWords
+	}
+	return nil
+}
+
+func (m *SlugifyRequest) GetExisting() []string {
+	if m != nil {
+		return m.Existing
+	}
+	return nil
+}
+
+type SlugifyResponse struct {
+	V   string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *SlugifyResponse) Reset()                    { *m = SlugifyResponse{} }
+func (m *SlugifyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*SlugifyResponse) ProtoMessage()               {}
+func (*SlugifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *SlugifyResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *SlugifyResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type SimilarityRequest struct {
+	A           string  `protobuf:"bytes,1,opt,name=a" json:"a,omitempty"`
+	B           string  `protobuf:"bytes,2,opt,name=b" json:"b,omitempty"`
+	Algorithm   string  `protobuf:"bytes,3,opt,name=algorithm" json:"algorithm,omitempty"`
+	MaxDistance float64 `protobuf:"fixed64,4,opt,name=max_distance,json=maxDistance" json:"max_distance,omitempty"`
+	Unit        string  `protobuf:"bytes,5,opt,name=unit" json:"unit,omitempty"`
+}
+
+func (m *SimilarityRequest) Reset()                    { *m = SimilarityRequest{} }
+func (m *SimilarityRequest) String() string            { return proto1.CompactTextString(m) }
+func (*SimilarityRequest) ProtoMessage()               {}
+func (*SimilarityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *SimilarityRequest) GetA() string {
+	if m != nil {
+		return m.A
+	}
+	return ""
+}
+
+func (m *SimilarityRequest) GetB() string {
+	if m != nil {
+		return m.B
+	}
+	return ""
+}
+
+func (m *SimilarityRequest) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *SimilarityRequest) GetMaxDistance() float64 {
+	if m != nil {
+		return m.MaxDistance
+	}
+	return 0
+}
+
+func (m *SimilarityRequest) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+type SimilarityResponse struct {
+	Distance float64 `protobuf:"fixed64,1,opt,name=distance" json:"distance,omitempty"`
+	Score    float64 `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	Exceeded bool    `protobuf:"varint,3,opt,name=exceeded" json:"exceeded,omitempty"`
+	Err      string  `protobuf:"bytes,4,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *SimilarityResponse) Reset()                    { *m = SimilarityResponse{} }
+func (m *SimilarityResponse) String() string            { return proto1.CompactTextString(m) }
+func (*SimilarityResponse) ProtoMessage()               {}
+func (*SimilarityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SimilarityResponse) GetDistance() float64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *SimilarityResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SimilarityResponse) GetExceeded() bool {
+	if m != nil {
+		return m.Exceeded
+	}
+	return false
+}
+
+func (m *SimilarityResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type MatchRequest struct {
+	S       string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Pattern string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+}
+
+func (m *MatchRequest) Reset()                    { *m = MatchRequest{} }
+func (m *MatchRequest) String() string            { return proto1.CompactTextString(m) }
+func (*MatchRequest) ProtoMessage()               {}
+func (*MatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *MatchRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *MatchRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+type MatchResponse struct {
+	V   bool   `protobuf:"varint,1,opt,name=v" json:"v,omitempty"`
+	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *MatchResponse) Reset()                    { *m = MatchResponse{} }
+func (m *MatchResponse) String() string            { return proto1.CompactTextString(m) }
+func (*MatchResponse) ProtoMessage()               {}
+func (*MatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *MatchResponse) GetV() bool {
+	if m != nil {
+		return m.V
+	}
+	return false
+}
+
+func (m *MatchResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type FindAllRequest struct {
+	S       string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Pattern string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+	Limit   int64  `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *FindAllRequest) Reset()                    { *m = FindAllRequest{} }
+func (m *FindAllRequest) String() string            { return proto1.CompactTextString(m) }
+func (*FindAllRequest) ProtoMessage()               {}
+func (*FindAllRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *FindAllRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *FindAllRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *FindAllRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RegexMatch struct {
+	V      string        `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Start  int64         `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	End    int64         `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+	Groups []*RegexGroup `protobuf:"bytes,4,rep,name=groups" json:"groups,omitempty"`
+}
+
+func (m *RegexMatch) Reset()                    { *m = RegexMatch{} }
+func (m *RegexMatch) String() string            { return proto1.CompactTextString(m) }
+func (*RegexMatch) ProtoMessage()               {}
+func (*RegexMatch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *RegexMatch) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *RegexMatch) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *RegexMatch) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *RegexMatch) GetGroups() []*RegexGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+type RegexGroup struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	V     string `protobuf:"bytes,2,opt,name=v" json:"v,omitempty"`
+	Start int64  `protobuf:"varint,3,opt,name=start" json:"start,omitempty"`
+	End   int64  `protobuf:"varint,4,opt,name=end" json:"end,omitempty"`
+}
+
+func (m *RegexGroup) Reset()                    { *m = RegexGroup{} }
+func (m *RegexGroup) String() string            { return proto1.CompactTextString(m) }
+func (*RegexGroup) ProtoMessage()               {}
+func (*RegexGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *RegexGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RegexGroup) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *RegexGroup) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *RegexGroup) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type FindAllResponse struct {
+	Matches   []*RegexMatch `protobuf:"bytes,1,rep,name=matches" json:"matches,omitempty"`
+	Truncated bool          `protobuf:"varint,2,opt,name=truncated" json:"truncated,omitempty"`
+	Err       string        `protobuf:"bytes,3,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *FindAllResponse) Reset()                    { *m = FindAllResponse{} }
+func (m *FindAllResponse) String() string            { return proto1.CompactTextString(m) }
+func (*FindAllResponse) ProtoMessage()               {}
+func (*FindAllResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *FindAllResponse) GetMatches() []*RegexMatch {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+func (m *FindAllResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *FindAllResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type ReplaceAllRequest struct {
+	S        string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Pattern  string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+	Template string `protobuf:"bytes,3,opt,name=template" json:"template,omitempty"`
+	Limit    int64  `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ReplaceAllRequest) Reset()                    { *m = ReplaceAllRequest{} }
+func (m *ReplaceAllRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ReplaceAllRequest) ProtoMessage()               {}
+func (*ReplaceAllRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ReplaceAllRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *ReplaceAllRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *ReplaceAllRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *ReplaceAllRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ReplaceAllResponse struct {
+	V         string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Replaced  int64  `protobuf:"varint,2,opt,name=replaced" json:"replaced,omitempty"`
+	Truncated bool   `protobuf:"varint,3,opt,name=truncated" json:"truncated,omitempty"`
+	Err       string `protobuf:"bytes,4,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *ReplaceAllResponse) Reset()                    { *m = ReplaceAllResponse{} }
+func (m *ReplaceAllResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ReplaceAllResponse) ProtoMessage()               {}
+func (*ReplaceAllResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ReplaceAllResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *ReplaceAllResponse) GetReplaced() int64 {
+	if m != nil {
+		return m.Replaced
+	}
+	return 0
+}
+
+func (m *ReplaceAllResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *ReplaceAllResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type WrapRequest struct {
+	S         string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Width     int64  `protobuf:"varint,2,opt,name=width" json:"width,omitempty"`
+	LongWords string `protobuf:"bytes,3,opt,name=long_words,json=longWords" json:"long_words,omitempty"`
+	Align     string `protobuf:"bytes,4,opt,name=align" json:"align,omitempty"`
+	Indent    int64  `protobuf:"varint,5,opt,name=indent" json:"indent,omitempty"`
+	Hanging   int64  `protobuf:"varint,6,opt,name=hanging" json:"hanging,omitempty"`
+}
+
+func (m *WrapRequest) Reset()                    { *m = WrapRequest{} }
+func (m *WrapRequest) String() string            { return proto1.CompactTextString(m) }
+func (*WrapRequest) ProtoMessage()               {}
+func (*WrapRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *WrapRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *WrapRequest) GetWidth() int64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *WrapRequest) GetLongWords() string {
+	if m != nil {
+		return m.LongWords
+	}
+	return ""
+}
+
+func (m *WrapRequest) GetAlign() string {
+	if m != nil {
+		return m.Align
+	}
+	return ""
+}
+
+func (m *WrapRequest) GetIndent() int64 {
+	if m != nil {
+		return m.Indent
+	}
+	return 0
+}
+
+func (m *WrapRequest) GetHanging() int64 {
+	if m != nil {
+		return m.Hanging
+	}
+	return 0
+}
+
+type WrapResponse struct {
+	V   string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Err string `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *WrapResponse) Reset()                    { *m = WrapResponse{} }
+func (m *WrapResponse) String() string            { return proto1.CompactTextString(m) }
+func (*WrapResponse) ProtoMessage()               {}
+func (*WrapResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *WrapResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *WrapResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type TruncateRequest struct {
+	S            string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Limit        int64  `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	Unit         string `protobuf:"bytes,3,opt,name=unit" json:"unit,omitempty"`
+	WordBoundary bool   `protobuf:"varint,4,opt,name=word_boundary,json=wordBoundary" json:"word_boundary,omitempty"`
+	Ellipsis     string `protobuf:"bytes,5,opt,name=ellipsis" json:"ellipsis,omitempty"`
+}
+
+func (m *TruncateRequest) Reset()                    { *m = TruncateRequest{} }
+func (m *TruncateRequest) String() string            { return proto1.CompactTextString(m) }
+func (*TruncateRequest) ProtoMessage()               {}
+func (*TruncateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *TruncateRequest) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+func (m *TruncateRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *TruncateRequest) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *TruncateRequest) GetWordBoundary() bool {
+	if m != nil {
+		return m.WordBoundary
+	}
+	return false
+}
+
+func (m *TruncateRequest) GetEllipsis() string {
+	if m != nil {
+		return m.Ellipsis
+	}
+	return ""
+}
+
+type TruncateResponse struct {
+	V         string `protobuf:"bytes,1,opt,name=v" json:"v,omitempty"`
+	Truncated bool   `protobuf:"varint,2,opt,name=truncated" json:"truncated,omitempty"`
+	Err       string `protobuf:"bytes,3,opt,name=err" json:"err,omitempty"`
+}
+
+func (m *TruncateResponse) Reset()                    { *m = TruncateResponse{} }
+func (m *TruncateResponse) String() string            { return proto1.CompactTextString(m) }
+func (*TruncateResponse) ProtoMessage()               {}
+func (*TruncateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *TruncateResponse) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+func (m *TruncateResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
 }
 
-func (m *RemoveWhitespaceResponse) GetErr() string {
+func (m *TruncateResponse) GetErr() string {
 	if m != nil {
 		return m.Err
 	}
 	return ""
 }
 
-type CountRequest struct {
-	S string `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+type VersionRequest struct {
 }
 
-func (m *CountRequest) Reset()                    { *m = CountRequest{} }
-func (m *CountRequest) String() string            { return proto1.CompactTextString(m) }
-func (*CountRequest) ProtoMessage()               {}
-func (*CountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *VersionRequest) Reset()                    { *m = VersionRequest{} }
+func (m *VersionRequest) String() string            { return proto1.CompactTextString(m) }
+func (*VersionRequest) ProtoMessage()               {}
+func (*VersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-func (m *CountRequest) GetS() string {
-	if m != nil {
-		return m.S
-	}
-	return ""
+type VersionResponse struct {
+	Version    int64 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	MinVersion int64 `protobuf:"varint,2,opt,name=min_version,json=minVersion" json:"min_version,omitempty"`
 }
 
-type CountResponse struct {
-	V int64 `protobuf:"varint,1,opt,name=v" json:"v,omitempty"`
-}
+func (m *VersionResponse) Reset()                    { *m = VersionResponse{} }
+func (m *VersionResponse) String() string            { return proto1.CompactTextString(m) }
+func (*VersionResponse) ProtoMessage()               {}
+func (*VersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-func (m *CountResponse) Reset()                    { *m = CountResponse{} }
-func (m *CountResponse) String() string            { return proto1.CompactTextString(m) }
-func (*CountResponse) ProtoMessage()               {}
-func (*CountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *VersionResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
 
-func (m *CountResponse) GetV() int64 {
+func (m *VersionResponse) GetMinVersion() int64 {
 	if m != nil {
-		return m.V
+		return m.MinVersion
 	}
 	return 0
 }
@@ -156,6 +1068,30 @@ func init() {
 	proto1.RegisterType((*RemoveWhitespaceResponse)(nil), "proto.RemoveWhitespaceResponse")
 	proto1.RegisterType((*CountRequest)(nil), "proto.CountRequest")
 	proto1.RegisterType((*CountResponse)(nil), "proto.CountResponse")
+	proto1.RegisterType((*ConvertCaseRequest)(nil), "proto.ConvertCaseRequest")
+	proto1.RegisterType((*ConvertCaseResponse)(nil), "proto.ConvertCaseResponse")
+	proto1.RegisterType((*StatsRequest)(nil), "proto.StatsRequest")
+	proto1.RegisterType((*StatsResponse)(nil), "proto.StatsResponse")
+	proto1.RegisterType((*NormalizeRequest)(nil), "proto.NormalizeRequest")
+	proto1.RegisterType((*NormalizeResponse)(nil), "proto.NormalizeResponse")
+	proto1.RegisterType((*SlugifyRequest)(nil), "proto.SlugifyRequest")
+	proto1.RegisterType((*SlugifyResponse)(nil), "proto.SlugifyResponse")
+	proto1.RegisterType((*SimilarityRequest)(nil), "proto.SimilarityRequest")
+	proto1.RegisterType((*SimilarityResponse)(nil), "proto.SimilarityResponse")
+	proto1.RegisterType((*MatchRequest)(nil), "proto.MatchRequest")
+	proto1.RegisterType((*MatchResponse)(nil), "proto.MatchResponse")
+	proto1.RegisterType((*FindAllRequest)(nil), "proto.FindAllRequest")
+	proto1.RegisterType((*RegexMatch)(nil), "proto.RegexMatch")
+	proto1.RegisterType((*RegexGroup)(nil), "proto.RegexGroup")
+	proto1.RegisterType((*FindAllResponse)(nil), "proto.FindAllResponse")
+	proto1.RegisterType((*ReplaceAllRequest)(nil), "proto.ReplaceAllRequest")
+	proto1.RegisterType((*ReplaceAllResponse)(nil), "proto.ReplaceAllResponse")
+	proto1.RegisterType((*WrapRequest)(nil), "proto.WrapRequest")
+	proto1.RegisterType((*WrapResponse)(nil), "proto.WrapResponse")
+	proto1.RegisterType((*TruncateRequest)(nil), "proto.TruncateRequest")
+	proto1.RegisterType((*TruncateResponse)(nil), "proto.TruncateResponse")
+	proto1.RegisterType((*VersionRequest)(nil), "proto.VersionRequest")
+	proto1.RegisterType((*VersionResponse)(nil), "proto.VersionResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -172,6 +1108,17 @@ type StringClient interface {
 	TitleCase(ctx context.Context, in *TitleCaseRequest, opts ...grpc.CallOption) (*TitleCaseResponse, error)
 	RemoveWhitespace(ctx context.Context, in *RemoveWhitespaceRequest, opts ...grpc.CallOption) (*RemoveWhitespaceResponse, error)
 	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	ConvertCase(ctx context.Context, in *ConvertCaseRequest, opts ...grpc.CallOption) (*ConvertCaseResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Normalize(ctx context.Context, in *NormalizeRequest, opts ...grpc.CallOption) (*NormalizeResponse, error)
+	Slugify(ctx context.Context, in *SlugifyRequest, opts ...grpc.CallOption) (*SlugifyResponse, error)
+	Similarity(ctx context.Context, in *SimilarityRequest, opts ...grpc.CallOption) (*SimilarityResponse, error)
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+	FindAll(ctx context.Context, in *FindAllRequest, opts ...grpc.CallOption) (*FindAllResponse, error)
+	ReplaceAll(ctx context.Context, in *ReplaceAllRequest, opts ...grpc.CallOption) (*ReplaceAllResponse, error)
+	Wrap(ctx context.Context, in *WrapRequest, opts ...grpc.CallOption) (*WrapResponse, error)
+	Truncate(ctx context.Context, in *TruncateRequest, opts ...grpc.CallOption) (*TruncateResponse, error)
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 }
 
 type stringClient struct {
@@ -209,12 +1156,122 @@ func (c *stringClient) Count(ctx context.Context, in *CountRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *stringClient) ConvertCase(ctx context.Context, in *ConvertCaseRequest, opts ...grpc.CallOption) (*ConvertCaseResponse, error) {
+	out := new(ConvertCaseResponse)
+	err := grpc.Invoke(ctx, "/proto.String/ConvertCase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Stats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Normalize(ctx context.Context, in *NormalizeRequest, opts ...grpc.CallOption) (*NormalizeResponse, error) {
+	out := new(NormalizeResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Normalize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Slugify(ctx context.Context, in *SlugifyRequest, opts ...grpc.CallOption) (*SlugifyResponse, error) {
+	out := new(SlugifyResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Slugify", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Similarity(ctx context.Context, in *SimilarityRequest, opts ...grpc.CallOption) (*SimilarityResponse, error) {
+	out := new(SimilarityResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Similarity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	out := new(MatchResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Match", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) FindAll(ctx context.Context, in *FindAllRequest, opts ...grpc.CallOption) (*FindAllResponse, error) {
+	out := new(FindAllResponse)
+	err := grpc.Invoke(ctx, "/proto.String/FindAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) ReplaceAll(ctx context.Context, in *ReplaceAllRequest, opts ...grpc.CallOption) (*ReplaceAllResponse, error) {
+	out := new(ReplaceAllResponse)
+	err := grpc.Invoke(ctx, "/proto.String/ReplaceAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Wrap(ctx context.Context, in *WrapRequest, opts ...grpc.CallOption) (*WrapResponse, error) {
+	out := new(WrapResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Wrap", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Truncate(ctx context.Context, in *TruncateRequest, opts ...grpc.CallOption) (*TruncateResponse, error) {
+	out := new(TruncateResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Truncate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stringClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := grpc.Invoke(ctx, "/proto.String/Version", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for String service
 
 type StringServer interface {
 	TitleCase(context.Context, *TitleCaseRequest) (*TitleCaseResponse, error)
 	RemoveWhitespace(context.Context, *RemoveWhitespaceRequest) (*RemoveWhitespaceResponse, error)
 	Count(context.Context, *CountRequest) (*CountResponse, error)
+	ConvertCase(context.Context, *ConvertCaseRequest) (*ConvertCaseResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Normalize(context.Context, *NormalizeRequest) (*NormalizeResponse, error)
+	Slugify(context.Context, *SlugifyRequest) (*SlugifyResponse, error)
+	Similarity(context.Context, *SimilarityRequest) (*SimilarityResponse, error)
+	Match(context.Context, *MatchRequest) (*MatchResponse, error)
+	FindAll(context.Context, *FindAllRequest) (*FindAllResponse, error)
+	ReplaceAll(context.Context, *ReplaceAllRequest) (*ReplaceAllResponse, error)
+	Wrap(context.Context, *WrapRequest) (*WrapResponse, error)
+	Truncate(context.Context, *TruncateRequest) (*TruncateResponse, error)
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 }
 
 func RegisterStringServer(s *grpc.Server, srv StringServer) {
@@ -275,6 +1332,204 @@ func _String_Count_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _String_ConvertCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).ConvertCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/ConvertCase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).ConvertCase(ctx, req.(*ConvertCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Normalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Normalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Normalize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Normalize(ctx, req.(*NormalizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Slugify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlugifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Slugify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Slugify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Slugify(ctx, req.(*SlugifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Similarity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimilarityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Similarity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Similarity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Similarity(ctx, req.(*SimilarityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Match_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Match(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Match",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Match(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_FindAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).FindAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/FindAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).FindAll(ctx, req.(*FindAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_ReplaceAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).ReplaceAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/ReplaceAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).ReplaceAll(ctx, req.(*ReplaceAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Wrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Wrap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Wrap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Wrap(ctx, req.(*WrapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Truncate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TruncateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Truncate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Truncate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Truncate(ctx, req.(*TruncateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _String_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.String/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _String_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.String",
 	HandlerType: (*StringServer)(nil),
@@ -291,6 +1546,50 @@ var _String_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Count",
 			Handler:    _String_Count_Handler,
 		},
+		{
+			MethodName: "ConvertCase",
+			Handler:    _String_ConvertCase_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _String_Stats_Handler,
+		},
+		{
+			MethodName: "Normalize",
+			Handler:    _String_Normalize_Handler,
+		},
+		{
+			MethodName: "Slugify",
+			Handler:    _String_Slugify_Handler,
+		},
+		{
+			MethodName: "Similarity",
+			Handler:    _String_Similarity_Handler,
+		},
+		{
+			MethodName: "Match",
+			Handler:    _String_Match_Handler,
+		},
+		{
+			MethodName: "FindAll",
+			Handler:    _String_FindAll_Handler,
+		},
+		{
+			MethodName: "ReplaceAll",
+			Handler:    _String_ReplaceAll_Handler,
+		},
+		{
+			MethodName: "Wrap",
+			Handler:    _String_Wrap_Handler,
+		},
+		{
+			MethodName: "Truncate",
+			Handler:    _String_Truncate_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _String_Version_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stringsvc.proto",
@@ -299,20 +1598,80 @@ var _String_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("stringsvc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 232 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x2e, 0x29, 0xca,
-	0xcc, 0x4b, 0x2f, 0x2e, 0x4b, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x4a,
-	0x0a, 0x5c, 0x02, 0x21, 0x99, 0x25, 0x39, 0xa9, 0xce, 0x89, 0xc5, 0xa9, 0x41, 0xa9, 0x85, 0xa5,
-	0xa9, 0xc5, 0x25, 0x42, 0x3c, 0x5c, 0x8c, 0xc5, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x8c,
-	0xc5, 0x4a, 0xc6, 0x5c, 0x82, 0x48, 0x2a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x41, 0x4a, 0xca,
-	0x60, 0x4a, 0xca, 0x84, 0x04, 0xb8, 0x98, 0x53, 0x8b, 0x8a, 0x24, 0x98, 0xc0, 0x7c, 0x10, 0x53,
-	0x49, 0x9d, 0x4b, 0x3c, 0x28, 0x35, 0x37, 0xbf, 0x2c, 0x35, 0x3c, 0x23, 0xb3, 0x24, 0xb5, 0xb8,
-	0x20, 0x31, 0x19, 0x87, 0xe9, 0x56, 0x5c, 0x12, 0x98, 0x0a, 0x89, 0xb4, 0x44, 0x86, 0x8b, 0xc7,
-	0x39, 0xbf, 0x34, 0xaf, 0x04, 0xbb, 0xc9, 0xb2, 0x5c, 0xbc, 0x50, 0x59, 0x74, 0xe3, 0x98, 0x83,
-	0x18, 0xcb, 0x8c, 0xae, 0x33, 0x72, 0xb1, 0x05, 0x83, 0xc3, 0x44, 0xc8, 0x81, 0x8b, 0x13, 0xee,
-	0x43, 0x21, 0x71, 0x48, 0xf8, 0xe8, 0xa1, 0x87, 0x8a, 0x94, 0x04, 0xa6, 0x04, 0xc4, 0x60, 0x25,
-	0x06, 0xa1, 0x50, 0x2e, 0x01, 0x74, 0x5f, 0x08, 0xc9, 0x41, 0xd5, 0xe3, 0x08, 0x07, 0x29, 0x79,
-	0x9c, 0xf2, 0x70, 0x63, 0x4d, 0xb8, 0x58, 0xc1, 0x5e, 0x10, 0x12, 0x86, 0xaa, 0x45, 0xf6, 0xae,
-	0x94, 0x08, 0xaa, 0x20, 0x4c, 0x57, 0x12, 0x1b, 0x58, 0xd8, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff,
-	0x2e, 0x8f, 0x67, 0xc9, 0xf3, 0x01, 0x00, 0x00,
+	// 1196 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5f, 0x6f, 0xe4, 0x34,
+	0x10, 0x27, 0xcd, 0xfe, 0xeb, 0x74, 0xdb, 0x6e, 0xdd, 0xd2, 0xa6, 0xd1, 0xfd, 0x23, 0xbc, 0x1c,
+	0x42, 0x2a, 0xdc, 0x1d, 0x20, 0x84, 0x84, 0x74, 0x50, 0x74, 0xf0, 0x70, 0x9c, 0x20, 0x3d, 0xae,
+	0x8f, 0x95, 0xbb, 0xeb, 0xcb, 0x5a, 0x4a, 0x9c, 0x60, 0x7b, 0x97, 0x96, 0x67, 0x9e, 0xf8, 0x00,
+	0x88, 0xef, 0xc4, 0x67, 0x42, 0x42, 0xfe, 0x9b, 0x6c, 0x36, 0x2b, 0xf5, 0x78, 0x4a, 0xe6, 0x67,
+	0xcf, 0xf8, 0x37, 0x33, 0x9e, 0xf1, 0xc0, 0xbe, 0x90, 0x9c, 0xb2, 0x4c, 0x2c, 0xa7, 0x67, 0x15,
+	0x2f, 0x65, 0x89, 0xfa, 0xfa, 0x93, 0xa4, 0x30, 0x79, 0x4d, 0x65, 0x4e, 0xce, 0xb1, 0x20, 0x29,
+	0xf9, 0x75, 0x41, 0x84, 0x44, 0x63, 0x08, 0x44, 0x14, 0x3c, 0x0a, 0x1e, 0x6f, 0xa7, 0x81, 0x40,
+	0x31, 0x8c, 0x72, 0xcc, 0xb2, 0x05, 0xce, 0x48, 0xb4, 0xa5, 0x41, 0x2f, 0xa3, 0x23, 0xe8, 0x0b,
+	0x79, 0x9b, 0x93, 0x28, 0xd4, 0x0b, 0x46, 0x48, 0x9e, 0xc1, 0x41, 0xc3, 0xa6, 0xa8, 0x4a, 0x26,
+	0x88, 0x32, 0xba, 0x74, 0x46, 0x97, 0x68, 0x02, 0x21, 0xe1, 0xdc, 0xda, 0x53, 0xbf, 0xc9, 0xcf,
+	0x70, 0x92, 0x92, 0xa2, 0x5c, 0x92, 0xcb, 0x39, 0x95, 0x44, 0x54, 0x78, 0xba, 0x81, 0x0f, 0x82,
+	0x5e, 0x51, 0xce, 0x1c, 0x17, 0xfd, 0xaf, 0x78, 0x4c, 0x73, 0x2c, 0x84, 0xe3, 0xa1, 0x85, 0x84,
+	0x43, 0xb4, 0x6e, 0xf2, 0x6e, 0x74, 0x50, 0x04, 0x43, 0xae, 0x75, 0x67, 0xda, 0x66, 0x98, 0x3a,
+	0x11, 0xdd, 0x83, 0xed, 0xaa, 0x14, 0x54, 0xd2, 0x92, 0x89, 0xa8, 0xf7, 0x28, 0x7c, 0x1c, 0xa6,
+	0x35, 0x90, 0x7c, 0x0a, 0xe3, 0xf3, 0x72, 0xc1, 0xe4, 0x46, 0xee, 0x0b, 0x46, 0xa5, 0xe3, 0xae,
+	0xfe, 0x93, 0x4f, 0x60, 0xd7, 0x6a, 0xb4, 0xa9, 0x85, 0xdd, 0x91, 0xfa, 0x12, 0xd0, 0x79, 0xc9,
+	0x96, 0x84, 0xcb, 0xcd, 0x49, 0xf3, 0x89, 0xd9, 0x6a, 0x26, 0xe6, 0x73, 0x38, 0x5c, 0xd1, 0xbc,
+	0x63, 0x6a, 0xee, 0xc1, 0xf8, 0x42, 0x62, 0x29, 0x3a, 0x8f, 0x4a, 0xfe, 0x09, 0x60, 0xd7, 0x2e,
+	0x5b, 0x7b, 0x47, 0xd0, 0xbf, 0xbe, 0x95, 0x44, 0x58, 0x27, 0x8c, 0xa0, 0x50, 0xbe, 0x60, 0x44,
+	0x68, 0xcb, 0x61, 0x6a, 0x04, 0x15, 0xcd, 0x8c, 0xe3, 0x6a, 0x4e, 0x0a, 0x22, 0x6c, 0xa4, 0x6b,
+	0x40, 0xe9, 0xfc, 0x56, 0xf2, 0x99, 0x8a, 0xb3, 0xd6, 0xd1, 0x82, 0x42, 0x73, 0xaa, 0x2c, 0xf5,
+	0x0d, 0x9a, 0x53, 0x6b, 0x49, 0x10, 0x26, 0x09, 0x9b, 0x12, 0x11, 0x0d, 0x8c, 0x25, 0x0f, 0x68,
+	0x4b, 0x74, 0x26, 0xe7, 0xd1, 0xd0, 0x5a, 0x52, 0x82, 0xf3, 0x75, 0x54, 0xfb, 0xfa, 0x03, 0x4c,
+	0x5e, 0x95, 0xbc, 0xc0, 0x39, 0xfd, 0x7d, 0xf3, 0xfd, 0x7b, 0x5b, 0xf2, 0xc2, 0xe5, 0x50, 0xfd,
+	0x1b, 0x2c, 0x37, 0x57, 0x65, 0x94, 0xea, 0xff, 0xe4, 0x02, 0x0e, 0x1a, 0x96, 0x3a, 0x43, 0xfd,
+	0x00, 0x80, 0xb9, 0x2d, 0x33, 0x6d, 0x70, 0x94, 0x36, 0x10, 0x47, 0x2f, 0xac, 0xe9, 0xfd, 0x15,
+	0xc0, 0xde, 0x45, 0xbe, 0xc8, 0xe8, 0xdb, 0xdb, 0x6e, 0x76, 0x3a, 0x0a, 0x15, 0xe6, 0x58, 0x96,
+	0x2e, 0x87, 0x35, 0x80, 0xee, 0x03, 0x14, 0xf8, 0xe6, 0x2a, 0x27, 0x2c, 0x93, 0x73, 0x17, 0xee,
+	0x02, 0xdf, 0xbc, 0xd4, 0x80, 0x5a, 0x16, 0xb2, 0xac, 0xae, 0x5c, 0xcc, 0x43, 0xad, 0x2d, 0xcb,
+	0xea, 0x52, 0xc7, 0x3d, 0x86, 0x11, 0xb9, 0xa1, 0x42, 0x52, 0x96, 0x45, 0x7d, 0xbd, 0xe8, 0xe5,
+	0xe4, 0x09, 0xec, 0x7b, 0x5e, 0x77, 0xbc, 0x56, 0x7f, 0x04, 0x70, 0x70, 0x41, 0x0b, 0x9a, 0x63,
+	0x4e, 0x65, 0xd3, 0x1d, 0xec, 0xb4, 0xb0, 0x92, 0xae, 0xad, 0x4e, 0x70, 0xad, 0x9c, 0xc3, 0x79,
+	0x56, 0x72, 0x2a, 0xe7, 0x85, 0x8d, 0x4a, 0x0d, 0xa0, 0x0f, 0x60, 0xac, 0x9c, 0x9b, 0x51, 0x21,
+	0x31, 0x9b, 0x12, 0x7d, 0x67, 0x82, 0x74, 0xa7, 0xc0, 0x37, 0xdf, 0x59, 0xc8, 0xd7, 0x5f, 0xbf,
+	0x51, 0x7f, 0x12, 0x50, 0x93, 0x85, 0x25, 0x1f, 0xc3, 0xc8, 0x1b, 0x0a, 0xb4, 0x21, 0x2f, 0xeb,
+	0xe2, 0x9a, 0x96, 0xdc, 0x14, 0x57, 0x90, 0x1a, 0xc1, 0x44, 0x67, 0x4a, 0xc8, 0x8c, 0xb8, 0x7b,
+	0xe0, 0x65, 0xe7, 0x7c, 0xaf, 0x76, 0xfe, 0x0b, 0x18, 0xff, 0x88, 0xe5, 0x74, 0xde, 0x9d, 0xc5,
+	0x08, 0x86, 0x15, 0x96, 0x92, 0x70, 0x66, 0x9d, 0x77, 0xa2, 0xea, 0x16, 0x56, 0xaf, 0x1d, 0xe5,
+	0x51, 0x77, 0x94, 0x5f, 0xc1, 0xde, 0x0b, 0xca, 0x66, 0xdf, 0xe4, 0xf9, 0x3b, 0x1e, 0x65, 0xca,
+	0xac, 0xa0, 0xd2, 0xde, 0x13, 0x23, 0x24, 0x05, 0x40, 0x4a, 0x32, 0x72, 0xa3, 0x59, 0xb4, 0x72,
+	0xac, 0xbb, 0x0e, 0xe6, 0xd2, 0x95, 0xb8, 0x16, 0x34, 0x27, 0xe6, 0xda, 0xa8, 0xfa, 0x45, 0x1f,
+	0xc1, 0x20, 0xe3, 0xe5, 0xa2, 0x32, 0x77, 0x6c, 0xe7, 0xe9, 0x81, 0x79, 0x93, 0xce, 0xb4, 0xe1,
+	0xef, 0xd5, 0x4a, 0x6a, 0x37, 0x24, 0x6f, 0x00, 0x6a, 0x54, 0xe5, 0x8f, 0xe1, 0x82, 0xd8, 0x13,
+	0xf5, 0xbf, 0xa1, 0xb0, 0xb5, 0x46, 0x21, 0xec, 0xa0, 0xd0, 0xf3, 0x14, 0x12, 0x06, 0xfb, 0x3e,
+	0x2c, 0x36, 0x92, 0x1f, 0xc3, 0xb0, 0x50, 0x4e, 0xe9, 0xc6, 0xb5, 0x46, 0xcb, 0x44, 0xdd, 0xed,
+	0x50, 0x57, 0x51, 0xf2, 0x05, 0x9b, 0x62, 0xe9, 0x2b, 0xb7, 0x06, 0x3a, 0x0a, 0xb7, 0x80, 0x83,
+	0x94, 0x54, 0x39, 0x9e, 0x92, 0xff, 0x91, 0x89, 0x18, 0x46, 0x92, 0x14, 0x55, 0x8e, 0xa5, 0x7b,
+	0x69, 0xbd, 0x5c, 0x67, 0xa9, 0xd7, 0xcc, 0x12, 0x03, 0xd4, 0x3c, 0xae, 0xb3, 0x22, 0x63, 0x18,
+	0x71, 0xb3, 0x67, 0x66, 0x13, 0xe6, 0xe5, 0x55, 0xf7, 0xc2, 0x0d, 0xee, 0x35, 0xae, 0xf3, 0xdf,
+	0x01, 0xec, 0x5c, 0x72, 0x5c, 0x6d, 0x7c, 0x8d, 0x4c, 0xf3, 0xdd, 0x6a, 0x36, 0xdf, 0xfb, 0x00,
+	0x79, 0xc9, 0x32, 0xdb, 0x6d, 0x6c, 0x39, 0x2b, 0xe4, 0xd2, 0x75, 0x79, 0x9c, 0xd3, 0x8c, 0xd9,
+	0x63, 0x8c, 0x80, 0x8e, 0x61, 0x40, 0xd9, 0x8c, 0x30, 0x69, 0x9b, 0xbf, 0x95, 0x54, 0xf0, 0xe6,
+	0x98, 0x65, 0xaa, 0x35, 0x99, 0xde, 0xef, 0xc4, 0xe4, 0x0c, 0xc6, 0x86, 0xd9, 0x1d, 0xdb, 0xd2,
+	0x9f, 0x01, 0xec, 0xbf, 0xb6, 0xae, 0x6e, 0x74, 0xc7, 0x84, 0x7c, 0xab, 0x11, 0x72, 0xdf, 0x5b,
+	0xc2, 0xba, 0xb7, 0xa0, 0x0f, 0x61, 0x57, 0x79, 0x77, 0x75, 0x5d, 0x2e, 0xd8, 0x0c, 0xf3, 0x5b,
+	0xed, 0xcb, 0x28, 0x1d, 0x2b, 0xf0, 0x5b, 0x8b, 0xe9, 0xc6, 0x91, 0xe7, 0xb4, 0x12, 0x54, 0xd8,
+	0xc6, 0xe4, 0xe5, 0xe4, 0x27, 0x98, 0xd4, 0x5c, 0x3a, 0x1d, 0x78, 0xd7, 0x8b, 0x38, 0x81, 0xbd,
+	0x37, 0x84, 0x0b, 0x5a, 0x32, 0xeb, 0x5c, 0xf2, 0x12, 0xf6, 0x3d, 0x62, 0x8f, 0x88, 0x60, 0xb8,
+	0x34, 0x90, 0x7d, 0xc3, 0x9d, 0x88, 0x1e, 0xc2, 0x4e, 0x41, 0xd9, 0x95, 0x5b, 0x35, 0x11, 0x80,
+	0x82, 0x32, 0x6b, 0xe2, 0xe9, 0xbf, 0x03, 0x18, 0x5c, 0xe8, 0x59, 0x13, 0x3d, 0x87, 0x6d, 0x3f,
+	0x07, 0xa2, 0x13, 0x5b, 0x4c, 0xed, 0x69, 0x33, 0x8e, 0xd6, 0x17, 0x0c, 0x8b, 0xe4, 0x3d, 0xf4,
+	0x0b, 0x4c, 0xda, 0x13, 0x1c, 0x7a, 0xe0, 0xab, 0xb2, 0x73, 0x5a, 0x8c, 0x1f, 0x6e, 0x5c, 0xf7,
+	0x66, 0x3f, 0x83, 0xbe, 0x1e, 0xb9, 0xd0, 0xa1, 0xdd, 0xdb, 0x1c, 0xd9, 0xe2, 0xa3, 0x55, 0xd0,
+	0x6b, 0xbd, 0x80, 0x9d, 0xc6, 0xf4, 0x84, 0x4e, 0xfd, 0xb6, 0xf6, 0x2c, 0x16, 0xc7, 0x5d, 0x4b,
+	0xcd, 0xd3, 0xf5, 0xbc, 0xe4, 0x4f, 0x6f, 0x0e, 0x57, 0xf1, 0xd1, 0x2a, 0xe8, 0xb5, 0x9e, 0xc3,
+	0xb6, 0x1f, 0x27, 0x7c, 0x30, 0xdb, 0xa3, 0x4a, 0x1c, 0xad, 0x2f, 0x78, 0x0b, 0x5f, 0xc1, 0xd0,
+	0x3e, 0xd1, 0xe8, 0x7d, 0x77, 0xc8, 0xca, 0x28, 0x11, 0x1f, 0xb7, 0x61, 0xaf, 0x7b, 0x0e, 0x50,
+	0x3f, 0x92, 0xc8, 0x9d, 0xb2, 0xf6, 0x7a, 0xc7, 0xa7, 0x1d, 0x2b, 0x4d, 0xc7, 0xcd, 0xab, 0xe1,
+	0x1c, 0x6f, 0xbe, 0x80, 0xf1, 0xd1, 0x2a, 0xd8, 0xa4, 0x6d, 0x3b, 0xb5, 0xa7, 0xbd, 0xfa, 0xa0,
+	0xc5, 0xc7, 0x6d, 0xb8, 0x49, 0xbb, 0x6e, 0x83, 0x9e, 0xf6, 0x5a, 0x23, 0x8e, 0x4f, 0x3b, 0x56,
+	0xbc, 0x91, 0x27, 0xd0, 0x53, 0x0d, 0x04, 0x21, 0xbb, 0xa9, 0xd1, 0xe7, 0xe2, 0xc3, 0x15, 0xcc,
+	0xab, 0x7c, 0x0d, 0x23, 0x57, 0xb6, 0xc8, 0xb1, 0x6b, 0xf5, 0x94, 0xf8, 0x64, 0x0d, 0x6f, 0xba,
+	0x6c, 0xcb, 0xc9, 0xbb, 0xbc, 0x5a, 0xb3, 0xf1, 0x71, 0x1b, 0x76, 0xba, 0xd7, 0x03, 0xbd, 0xf0,
+	0xec, 0xbf, 0x01, 0x00, 0x7a, 0x0e, 0x83, 0xa6, 0xf1, 0x0d, 0x00, 0x00,
 }
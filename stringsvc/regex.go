@@ -0,0 +1,332 @@
+package stringsvc
+
+// Regular expression operations of Match, FindAll and ReplaceAll, with
+// the limits they keep to and the cache of compiled patterns they share.
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegexLimits bound the work of a single call of the regular expression
+// operations. Patterns use the RE2 syntax of package regexp, whose matching
+// takes time linear in the size of the input.
+type RegexLimits struct {
+	MaxPattern int `yaml:"max_pattern" usage:"length in bytes of the longest regular expression accepted"`
+	MaxInput   int `yaml:"max_input" usage:"size in bytes of the largest input of the regular expression operations"`
+	MaxMatches int `yaml:"max_matches" usage:"number of matches found or replaced by a call at most"`
+	MaxOutput  int `yaml:"max_output" usage:"size in bytes of the largest output of ReplaceAll"`
+	CacheSize  int `yaml:"cache_size" usage:"number of compiled regular expressions kept"`
+}
+
+// DefaultRegexLimits returns the limits of the service returned by New.
+func DefaultRegexLimits() RegexLimits {
+	return RegexLimits{
+		MaxPattern: 1 << 10,
+		MaxInput:   1 << 20,
+		MaxMatches: 1000,
+		MaxOutput:  4 << 20,
+		CacheSize:  256,
+	}
+}
+
+// Validate checks that the limits allow calls.
+func (l RegexLimits) Validate() error {
+	switch {
+	case l.MaxPattern < 1:
+		return fmt.Errorf("max_pattern must be positive, got %d", l.MaxPattern)
+	case l.MaxInput < 1:
+		return fmt.Errorf("max_input must be positive, got %d", l.MaxInput)
+	case l.MaxMatches < 1:
+		return fmt.Errorf("max_matches must be positive, got %d", l.MaxMatches)
+	case l.MaxOutput < 1:
+		return fmt.Errorf("max_output must be positive, got %d", l.MaxOutput)
+	case l.CacheSize < 0:
+		return fmt.Errorf("cache_size must not be negative, got %d", l.CacheSize)
+	}
+	return nil
+}
+
+// RegexMatch is a match of FindAll. Offsets are counted in runes from the
+// start of the input, End being the offset past the match.
+type RegexMatch struct {
+	V      string       `json:"v"`
+	Start  int          `json:"start"`
+	End    int          `json:"end"`
+	Groups []RegexGroup `json:"groups,omitempty"`
+}
+
+// RegexGroup is the text matched by a capture group of the pattern, in
+// the order of their opening parentheses. Start and End are -1 for groups
+// that did not take part in the match.
+type RegexGroup struct {
+	Name  string `json:"name,omitempty"`
+	V     string `json:"v"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// FindAllResult is the outcome of FindAll.
+type FindAllResult struct {
+	Matches []RegexMatch `json:"matches"`
+	// Truncated reports that there are more matches than the limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ReplaceAllResult is the outcome of ReplaceAll.
+type ReplaceAllResult struct {
+	V        string `json:"v"`
+	Replaced int    `json:"replaced"`
+	// Truncated reports that matches past the limit were left as they are.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// PatternError is returned for patterns that do not compile or exceed the
+// length limit. It is answered with HTTP status 400, along with the offset
+// in the JSON-encoded body, and gRPC status InvalidArgument, along with the
+// offset in an ErrorInfo detail.
+type PatternError struct {
+	Pattern string
+	// Offset is the offset in runes of the part of Pattern in error, -1
+	// if the error is not at a single place, such as a missing parenthesis.
+	Offset int
+	Reason string
+}
+
+func (e PatternError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("invalid pattern %q: %s", e.Pattern, e.Reason)
+	}
+	return fmt.Sprintf("invalid pattern %q at offset %d: %s", e.Pattern, e.Offset, e.Reason)
+}
+
+// StatusCode implements transport/http.StatusCoder.
+func (e PatternError) StatusCode() int { return http.StatusBadRequest }
+
+// patternErrorReason is the reason of the ErrorInfo detail of PatternError.
+const patternErrorReason = "INVALID_PATTERN"
+
+// GRPCStatus lets the gRPC server answer with the InvalidArgument status
+// and the pattern, offset and reason in an ErrorInfo detail.
+func (e PatternError) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+	info := &errdetails.ErrorInfo{
+		Reason:   patternErrorReason,
+		Domain:   "stringsvc",
+		Metadata: map[string]string{"pattern": e.Pattern, "reason": e.Reason},
+	}
+	if e.Offset >= 0 {
+		info.Metadata["offset"] = strconv.Itoa(e.Offset)
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// patternErrorFromStatus returns the PatternError whose GRPCStatus is st.
+func patternErrorFromStatus(st *status.Status) (PatternError, bool) {
+	if st.Code() != codes.InvalidArgument {
+		return PatternError{}, false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.Reason != patternErrorReason {
+			continue
+		}
+		perr := PatternError{Pattern: info.Metadata["pattern"], Offset: -1, Reason: info.Metadata["reason"]}
+		if offset, err := strconv.Atoi(info.Metadata["offset"]); err == nil {
+			perr.Offset = offset
+		}
+		return perr, true
+	}
+	return PatternError{}, false
+}
+
+// MarshalJSON lets the HTTP server answer with the error and its offset,
+// if known, see errorWrapper.
+func (e PatternError) MarshalJSON() ([]byte, error) {
+	w := errorWrapper{Error: e.Error(), Pattern: &e.Pattern, Reason: e.Reason}
+	if e.Offset >= 0 {
+		w.Offset = &e.Offset
+	}
+	return json.Marshal(w)
+}
+
+// regexEngine compiles patterns within the limits and caches the most
+// recently used ones.
+type regexEngine struct {
+	limits RegexLimits
+
+	mu    sync.Mutex
+	order *list.List // of *regexp.Regexp, most recently used first
+	cache map[string]*list.Element
+}
+
+func newRegexEngine(limits RegexLimits) *regexEngine {
+	return &regexEngine{
+		limits: limits,
+		order:  list.New(),
+		cache:  map[string]*list.Element{},
+	}
+}
+
+// compile returns the compiled pattern, checking s against the input limit.
+func (e *regexEngine) compile(s, pattern string) (*regexp.Regexp, error) {
+	if len(s) > e.limits.MaxInput {
+		return nil, policyError{fmt.Sprintf("input of %d bytes exceeds the limit of %d bytes", len(s), e.limits.MaxInput), http.StatusRequestEntityTooLarge, codes.ResourceExhausted}
+	}
+	if len(pattern) > e.limits.MaxPattern {
+		return nil, PatternError{
+			Pattern: pattern,
+			Offset:  utf8.RuneCountInString(pattern[:e.limits.MaxPattern]),
+			Reason:  fmt.Sprintf("pattern exceeds the limit of %d bytes", e.limits.MaxPattern),
+		}
+	}
+
+	e.mu.Lock()
+	if el, ok := e.cache[pattern]; ok {
+		e.order.MoveToFront(el)
+		e.mu.Unlock()
+		return el.Value.(*regexp.Regexp), nil
+	}
+	e.mu.Unlock()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		perr := PatternError{Pattern: pattern, Offset: -1, Reason: err.Error()}
+		if serr, ok := err.(*syntax.Error); ok {
+			perr.Reason = serr.Code.String()
+			// Expr is the part of the pattern in error, or all of it for
+			// errors that are not at a single place. Parsing stops at the
+			// first error, so the first copy of Expr is the one blamed.
+			if serr.Expr != "" && len(serr.Expr) < len(pattern) {
+				if i := strings.Index(pattern, serr.Expr); i >= 0 {
+					perr.Offset = utf8.RuneCountInString(pattern[:i])
+				}
+			}
+		}
+		return nil, perr
+	}
+	if e.limits.CacheSize == 0 {
+		return re, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.cache[pattern]; !ok {
+		e.cache[pattern] = e.order.PushFront(re)
+		if e.order.Len() > e.limits.CacheSize {
+			oldest := e.order.Remove(e.order.Back()).(*regexp.Regexp)
+			delete(e.cache, oldest.String())
+		}
+	}
+	return re, nil
+}
+
+// limit returns the number of matches a call asking for n may find.
+func (e *regexEngine) limit(n int) int {
+	if n <= 0 || n > e.limits.MaxMatches {
+		return e.limits.MaxMatches
+	}
+	return n
+}
+
+func (e *regexEngine) match(s, pattern string) (bool, error) {
+	re, err := e.compile(s, pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+func (e *regexEngine) findAll(s, pattern string, n int) (FindAllResult, error) {
+	re, err := e.compile(s, pattern)
+	if err != nil {
+		return FindAllResult{}, err
+	}
+	n = e.limit(n)
+	found := re.FindAllStringSubmatchIndex(s, n+1)
+	res := FindAllResult{Matches: []RegexMatch{}}
+	if len(found) > n {
+		found, res.Truncated = found[:n], true
+	}
+	names := re.SubexpNames()
+	offsets := runeOffsets{s: s}
+	for _, loc := range found {
+		start := offsets.at(loc[0])
+		m := RegexMatch{V: s[loc[0]:loc[1]], Start: start, End: start + utf8.RuneCountInString(s[loc[0]:loc[1]])}
+		for g := 1; g < len(names); g++ {
+			group := RegexGroup{Name: names[g], Start: -1, End: -1}
+			if i, j := loc[2*g], loc[2*g+1]; i >= 0 {
+				group.V = s[i:j]
+				group.Start = start + utf8.RuneCountInString(s[loc[0]:i])
+				group.End = group.Start + utf8.RuneCountInString(group.V)
+			}
+			m.Groups = append(m.Groups, group)
+		}
+		res.Matches = append(res.Matches, m)
+	}
+	return res, nil
+}
+
+func (e *regexEngine) replaceAll(s, pattern, template string, n int) (ReplaceAllResult, error) {
+	re, err := e.compile(s, pattern)
+	if err != nil {
+		return ReplaceAllResult{}, err
+	}
+	n = e.limit(n)
+	found := re.FindAllStringSubmatchIndex(s, n+1)
+	var res ReplaceAllResult
+	if len(found) > n {
+		found, res.Truncated = found[:n], true
+	}
+	var (
+		out  []byte
+		last int
+	)
+	for _, loc := range found {
+		out = append(out, s[last:loc[0]]...)
+		out = re.ExpandString(out, template, s, loc)
+		last = loc[1]
+		// Templates repeating the match, like $0$0$0, can make the
+		// output much larger than the input.
+		if len(out) > e.limits.MaxOutput {
+			return ReplaceAllResult{}, e.outputError()
+		}
+	}
+	if len(out)+len(s)-last > e.limits.MaxOutput {
+		return ReplaceAllResult{}, e.outputError()
+	}
+	res.V = string(append(out, s[last:]...))
+	res.Replaced = len(found)
+	return res, nil
+}
+
+func (e *regexEngine) outputError() error {
+	return policyError{fmt.Sprintf("output exceeds the limit of %d bytes", e.limits.MaxOutput), http.StatusRequestEntityTooLarge, codes.ResourceExhausted}
+}
+
+// runeOffsets converts ascending byte offsets in s to rune offsets.
+type runeOffsets struct {
+	s          string
+	byte, rune int
+}
+
+func (o *runeOffsets) at(i int) int {
+	o.rune += utf8.RuneCountInString(o.s[o.byte:i])
+	o.byte = i
+	return o.rune
+}
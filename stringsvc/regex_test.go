@@ -0,0 +1,55 @@
+package stringsvc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReplaceAllOutputLimit(t *testing.T) {
+	limits := DefaultRegexLimits()
+	limits.MaxOutput = 100
+	e := newRegexEngine(limits)
+
+	if _, err := e.replaceAll(strings.Repeat("a", 10), "a+", strings.Repeat("$0", 10), 0); err != nil {
+		t.Errorf("output of 100 bytes: %v", err)
+	}
+	for _, tc := range []struct {
+		s, pattern, template string
+	}{
+		{strings.Repeat("a", 10), "a+", strings.Repeat("$0", 11)},
+		{strings.Repeat("a", 60), "a", "$0$0"},
+	} {
+		_, err := e.replaceAll(tc.s, tc.pattern, tc.template, 0)
+		perr, ok := err.(policyError)
+		if !ok || perr.StatusCode() != http.StatusRequestEntityTooLarge {
+			t.Errorf("%q: got error %v, want status %d", tc.template, err, http.StatusRequestEntityTooLarge)
+		}
+	}
+}
+
+func TestPatternErrorOffset(t *testing.T) {
+	e := newRegexEngine(DefaultRegexLimits())
+	for _, tc := range []struct {
+		pattern string
+		offset  int
+	}{
+		{"[z-a]", 1},
+		{"é[z-a]", 2},
+		// The first of the repeated expressions in error is reported,
+		// where parsing stops.
+		{"xa**|ya**", 2},
+		{"(a", -1},
+		{"a(b", -1},
+	} {
+		_, err := e.match("", tc.pattern)
+		perr, ok := err.(PatternError)
+		if !ok {
+			t.Errorf("%q: got %v, want a PatternError", tc.pattern, err)
+			continue
+		}
+		if perr.Offset != tc.offset {
+			t.Errorf("%q: got offset %d, want %d", tc.pattern, perr.Offset, tc.offset)
+		}
+	}
+}
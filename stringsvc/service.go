@@ -6,50 +6,151 @@ package stringsvc
 import (
 	"context"
 	"errors"
-	"strings"
-	"unicode"
+	"fmt"
+	"net/http"
 )
 
 // StringService provides operations on strings
 type StringService interface {
-	TitleCase(context.Context, string) (string, error)
-	RemoveWhitespace(context.Context, string) (string, error)
+	TitleCase(ctx context.Context, s string, opts TitleCaseOptions) (string, error)
+	RemoveWhitespace(ctx context.Context, s string, opts WhitespaceOptions) (output string, removed []int, err error)
 	Count(context.Context, string) int
+	CountUnit(ctx context.Context, s, unit string) (int, error)
+	ConvertCase(ctx context.Context, s, style string) (string, error)
+	Stats(ctx context.Context, s string) (TextStats, error)
+	Normalize(ctx context.Context, s string, opts NormalizeOptions) (output string, normalized bool, err error)
+	Slugify(ctx context.Context, s string, opts SlugOptions) (string, error)
+	Similarity(ctx context.Context, a, b string, opts SimilarityOptions) (SimilarityResult, error)
+	Match(ctx context.Context, s, pattern string) (bool, error)
+	FindAll(ctx context.Context, s, pattern string, limit int) (FindAllResult, error)
+	ReplaceAll(ctx context.Context, s, pattern, template string, limit int) (ReplaceAllResult, error)
+	Wrap(ctx context.Context, s string, opts WrapOptions) (string, error)
+	Truncate(ctx context.Context, s string, opts TruncateOptions) (output string, truncated bool, err error)
 }
 
-type stringService struct{}
+type stringService struct {
+	regex *regexEngine
+}
 
-// New returns a stateless implementation of StringService
+// New returns an implementation of StringService keeping to the
+// DefaultRegexLimits. Its only state is the cache of compiled patterns.
 func New() StringService {
-	return stringService{}
+	return NewWithRegexLimits(DefaultRegexLimits())
+}
+
+// NewWithRegexLimits returns an implementation of StringService whose
+// regular expression operations keep to limits.
+func NewWithRegexLimits(limits RegexLimits) StringService {
+	return stringService{regex: newRegexEngine(limits)}
 }
 
 // ErrEmptyString protects the TitleCase and RemoveWhitespace
 // from executing on empty strings that will have no effect
-var ErrEmptyString = errors.New("empty string")
+var ErrEmptyString error = inputError{errors.New("empty string")}
+
+// inputError is returned for requests that cannot be served as given, such
+// as an unknown option, as opposed to failures of the service itself.
+type inputError struct{ error }
+
+// invalidInput formats an inputError like fmt.Errorf.
+func invalidInput(format string, a ...interface{}) error {
+	return inputError{fmt.Errorf(format, a...)}
+}
+
+// clientError reports whether err was caused by the request rather than
+// the service: an inputError or one answered with a 4xx HTTP status, like
+// a PatternError.
+func clientError(err error) bool {
+	if _, ok := err.(inputError); ok {
+		return true
+	}
+	if sc, ok := err.(interface{ StatusCode() int }); ok {
+		return sc.StatusCode() >= http.StatusBadRequest && sc.StatusCode() < http.StatusInternalServerError
+	}
+	return false
+}
 
 // TitleCase implements StringService
-func (stringService) TitleCase(_ context.Context, s string) (string, error) {
+func (stringService) TitleCase(_ context.Context, s string, opts TitleCaseOptions) (string, error) {
 	if s == "" {
 		return "", ErrEmptyString
 	}
-	return strings.Title(s), nil
+	return titleCase(s, opts)
 }
 
 // RemoveWhitespace implements StringService
-func (stringService) RemoveWhitespace(_ context.Context, s string) (string, error) {
+func (stringService) RemoveWhitespace(_ context.Context, s string, opts WhitespaceOptions) (string, []int, error) {
 	if s == "" {
-		return "", ErrEmptyString
+		return "", nil, ErrEmptyString
 	}
-	return strings.Map(func(r rune) rune {
-		if unicode.IsSpace(r) {
-			return -1
-		}
-		return r
-	}, s), nil
+	return removeWhitespace(s, opts)
 }
 
 // Count implements StringService
 func (stringService) Count(_ context.Context, s string) int {
 	return len(s)
 }
+
+// CountUnit implements StringService
+func (stringService) CountUnit(_ context.Context, s, unit string) (int, error) {
+	return countUnit(s, unit)
+}
+
+// ConvertCase implements StringService
+func (stringService) ConvertCase(_ context.Context, s, style string) (string, error) {
+	if s == "" {
+		return "", ErrEmptyString
+	}
+	return convertCase(s, style)
+}
+
+// Stats implements StringService
+func (stringService) Stats(_ context.Context, s string) (TextStats, error) {
+	return textStats(s), nil
+}
+
+// Normalize implements StringService
+func (stringService) Normalize(_ context.Context, s string, opts NormalizeOptions) (string, bool, error) {
+	if s == "" {
+		return "", false, ErrEmptyString
+	}
+	return normalize(s, opts)
+}
+
+// Slugify implements StringService
+func (stringService) Slugify(_ context.Context, s string, opts SlugOptions) (string, error) {
+	if s == "" {
+		return "", ErrEmptyString
+	}
+	return slugify(s, opts)
+}
+
+// Similarity implements StringService
+func (stringService) Similarity(_ context.Context, a, b string, opts SimilarityOptions) (SimilarityResult, error) {
+	return similarity(a, b, opts)
+}
+
+// Match implements StringService
+func (svc stringService) Match(_ context.Context, s, pattern string) (bool, error) {
+	return svc.regex.match(s, pattern)
+}
+
+// FindAll implements StringService
+func (svc stringService) FindAll(_ context.Context, s, pattern string, limit int) (FindAllResult, error) {
+	return svc.regex.findAll(s, pattern, limit)
+}
+
+// ReplaceAll implements StringService
+func (svc stringService) ReplaceAll(_ context.Context, s, pattern, template string, limit int) (ReplaceAllResult, error) {
+	return svc.regex.replaceAll(s, pattern, template, limit)
+}
+
+// Wrap implements StringService
+func (stringService) Wrap(_ context.Context, s string, opts WrapOptions) (string, error) {
+	return wrapText(s, opts)
+}
+
+// Truncate implements StringService
+func (stringService) Truncate(_ context.Context, s string, opts TruncateOptions) (string, bool, error) {
+	return truncate(s, opts)
+}
@@ -0,0 +1,270 @@
+package stringsvc
+
+// Edit distances and similarity scores of Similarity, computed over the
+// runes or grapheme clusters of the strings compared.
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Algorithms of Similarity.
+const (
+	AlgorithmLevenshtein = "levenshtein"  // insertions, deletions and substitutions
+	AlgorithmDamerau     = "damerau"      // as levenshtein, plus transpositions of adjacent characters
+	AlgorithmJaroWinkler = "jaro_winkler" // matching characters and transpositions, favoring common prefixes
+	AlgorithmLCS         = "lcs"          // longest common subsequence
+)
+
+// SimilarityAlgorithms lists the algorithms of Similarity.
+var SimilarityAlgorithms = []string{AlgorithmLevenshtein, AlgorithmDamerau, AlgorithmJaroWinkler, AlgorithmLCS}
+
+// SimilarityOptions of Similarity. The zero value computes the Levenshtein
+// distance of runes without a cutoff.
+type SimilarityOptions struct {
+	// Algorithm is one of SimilarityAlgorithms, AlgorithmLevenshtein if empty.
+	Algorithm string
+	// MaxDistance, if positive, is the distance beyond which strings are
+	// reported as too far apart. Levenshtein and Damerau distances stop
+	// being computed once they are known to exceed it.
+	MaxDistance float64
+	// Unit is UnitRunes or UnitGraphemes, UnitRunes if empty.
+	Unit string
+}
+
+// SimilarityResult is the outcome of Similarity.
+type SimilarityResult struct {
+	// Distance is the number of edits for levenshtein and damerau, the
+	// number of characters inserted or deleted for lcs and one minus the
+	// score for jaro_winkler.
+	Distance float64 `json:"distance"`
+	// Score is the similarity normalized to the range from 0, nothing in
+	// common, to 1, equal.
+	Score float64 `json:"score"`
+	// Exceeded reports that the distance exceeds MaxDistance. The actual
+	// distance is then at least Distance and the actual score at most Score.
+	Exceeded bool `json:"exceeded,omitempty"`
+}
+
+// similarity compares a and b as in opts.
+func similarity(a, b string, opts SimilarityOptions) (SimilarityResult, error) {
+	var split func(string) []string
+	switch opts.Unit {
+	case "", UnitRunes:
+		split = splitRunes
+	case UnitGraphemes:
+		split = splitGraphemes
+	default:
+		return SimilarityResult{}, invalidInput("unknown unit %q, want %s or %s", opts.Unit, UnitRunes, UnitGraphemes)
+	}
+	x, y := symbols(split(a), split(b))
+
+	var res SimilarityResult
+	switch opts.Algorithm {
+	case "", AlgorithmLevenshtein:
+		res = editSimilarity(x, y, opts.MaxDistance, false)
+	case AlgorithmDamerau:
+		res = editSimilarity(x, y, opts.MaxDistance, true)
+	case AlgorithmJaroWinkler:
+		score := jaroWinkler(x, y)
+		res = SimilarityResult{Distance: 1 - score, Score: score}
+	case AlgorithmLCS:
+		n := lcs(x, y)
+		res = SimilarityResult{Distance: float64(len(x) + len(y) - 2*n), Score: 1}
+		if len(x)+len(y) > 0 {
+			res.Score = float64(2*n) / float64(len(x)+len(y))
+		}
+	default:
+		return SimilarityResult{}, invalidInput("unknown algorithm %q, want one of %s", opts.Algorithm, strings.Join(SimilarityAlgorithms, ", "))
+	}
+	if opts.MaxDistance > 0 && res.Distance > opts.MaxDistance {
+		res.Exceeded = true
+	}
+	return res, nil
+}
+
+func splitRunes(s string) []string {
+	var out []string
+	for _, r := range s {
+		out = append(out, string(r))
+	}
+	return out
+}
+
+func splitGraphemes(s string) []string {
+	var out []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		out = append(out, g.Str())
+	}
+	return out
+}
+
+// symbols numbers the distinct characters of a and b, so that they are
+// compared as integers.
+func symbols(a, b []string) ([]int, []int) {
+	ids := make(map[string]int)
+	number := func(chars []string) []int {
+		out := make([]int, len(chars))
+		for i, c := range chars {
+			id, ok := ids[c]
+			if !ok {
+				id = len(ids)
+				ids[c] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+	return number(a), number(b)
+}
+
+// editSimilarity computes the Levenshtein distance of a and b, or their
+// optimal string alignment distance, which also counts a transposition of
+// adjacent characters as one edit, if transpose is set. A row whose every
+// distance exceeds max ends the computation, if max is positive.
+func editSimilarity(a, b []int, max float64, transpose bool) SimilarityResult {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	result := func(d int, exceeded bool) SimilarityResult {
+		res := SimilarityResult{Distance: float64(d), Score: 1, Exceeded: exceeded}
+		if longest > 0 {
+			res.Score = 1 - float64(d)/float64(longest)
+		}
+		return res
+	}
+	if max > 0 {
+		if diff := len(a) - len(b); float64(diff) > max || float64(-diff) > max {
+			if diff < 0 {
+				diff = -diff
+			}
+			return result(diff, true)
+		}
+	}
+
+	prev2 := make([]int, len(b)+1) // row i-2, for transpositions
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && prev2[j-2]+1 < d {
+				d = prev2[j-2] + 1
+			}
+			cur[j] = d
+			if d < rowMin {
+				rowMin = d
+			}
+		}
+		if max > 0 && float64(rowMin) > max {
+			return result(rowMin, true)
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return result(prev[len(b)], false)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, the Jaro
+// similarity raised by a tenth of the remainder for each of up to four
+// common leading characters.
+func jaroWinkler(a, b []int) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	window := len(a)
+	if len(b) > window {
+		window = len(b)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < 4 && prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// lcs returns the length of the longest common subsequence of a and b.
+func lcs(a, b []int) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
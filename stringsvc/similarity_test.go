@@ -0,0 +1,80 @@
+package stringsvc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		opts     SimilarityOptions
+		distance float64
+		score    float64
+		exceeded bool
+	}{
+		{"", "", SimilarityOptions{}, 0, 1, false},
+		{"abc", "", SimilarityOptions{}, 3, 0, false},
+		{"kitten", "sitting", SimilarityOptions{}, 3, 1 - 3.0/7, false},
+		{"teh", "the", SimilarityOptions{Algorithm: AlgorithmLevenshtein}, 2, 1 - 2.0/3, false},
+		{"teh", "the", SimilarityOptions{Algorithm: AlgorithmDamerau}, 1, 1 - 1.0/3, false},
+		{"ca", "abc", SimilarityOptions{Algorithm: AlgorithmDamerau}, 3, 0, false},
+		{"MARTHA", "MARHTA", SimilarityOptions{Algorithm: AlgorithmJaroWinkler}, 1 - 0.961111, 0.961111, false},
+		{"DWAYNE", "DUANE", SimilarityOptions{Algorithm: AlgorithmJaroWinkler}, 1 - 0.84, 0.84, false},
+		{"DIXON", "DICKSONX", SimilarityOptions{Algorithm: AlgorithmJaroWinkler}, 1 - 0.813333, 0.813333, false},
+		{"abc", "xyz", SimilarityOptions{Algorithm: AlgorithmJaroWinkler}, 1, 0, false},
+		{"ABCBDAB", "BDCABA", SimilarityOptions{Algorithm: AlgorithmLCS}, 5, 8.0 / 13, false},
+		{"👍🏽a", "a", SimilarityOptions{}, 2, 1 - 2.0/3, false},
+		{"👍🏽a", "a", SimilarityOptions{Unit: UnitGraphemes}, 1, 0.5, false},
+		{"\u00e9", "e\u0301", SimilarityOptions{}, 2, 0, false},
+		{"\u00e9", "e\u0301", SimilarityOptions{Unit: UnitGraphemes}, 1, 0, false},
+		{"kitten", "sitting", SimilarityOptions{MaxDistance: 3}, 3, 1 - 3.0/7, false},
+		{"kitten", "sitting", SimilarityOptions{MaxDistance: 2}, 3, 1 - 3.0/7, true},
+		{"abcdef", "uvwxyz", SimilarityOptions{MaxDistance: 2}, 3, 0.5, true},
+		{"a", "abcdef", SimilarityOptions{Algorithm: AlgorithmDamerau, MaxDistance: 2}, 5, 1 - 5.0/6, true},
+		{"ABCBDAB", "BDCABA", SimilarityOptions{Algorithm: AlgorithmLCS, MaxDistance: 4}, 5, 8.0 / 13, true},
+	} {
+		name := fmt.Sprintf("%s/%q/%q", tc.opts.Algorithm, tc.a, tc.b)
+		res, err := similarity(tc.a, tc.b, tc.opts)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if math.Abs(res.Distance-tc.distance) > 1e-6 || math.Abs(res.Score-tc.score) > 1e-6 || res.Exceeded != tc.exceeded {
+			t.Errorf("%s: got %+v, want distance %v, score %v, exceeded %v", name, res, tc.distance, tc.score, tc.exceeded)
+		}
+	}
+}
+
+func TestSimilarityInvalidOptions(t *testing.T) {
+	for _, opts := range []SimilarityOptions{
+		{Algorithm: "hamming"},
+		{Unit: UnitWords},
+	} {
+		if _, err := similarity("a", "b", opts); err == nil {
+			t.Errorf("%+v: no error", opts)
+		}
+	}
+}
+
+func BenchmarkSimilarity(b *testing.B) {
+	for _, n := range []int{16, 1024} {
+		// Strings of n characters differing in about one of eight.
+		x := strings.Repeat("héllo wö", n/8)
+		y := strings.Repeat("hallo wö", n/8)
+		for _, algorithm := range SimilarityAlgorithms {
+			for _, unit := range []string{UnitRunes, UnitGraphemes} {
+				opts := SimilarityOptions{Algorithm: algorithm, Unit: unit}
+				b.Run(fmt.Sprintf("%s/%s/%d", algorithm, unit, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						if _, err := similarity(x, y, opts); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package stringsvc
+
+// URL slugs of Slugify, made of the lower cased words of a text with
+// their diacritics removed.
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugOptions of Slugify. The zero value joins all words with hyphens
+// and puts no limit on the length.
+type SlugOptions struct {
+	// Separator joins the words, "-" if empty. It may not hold letters
+	// or digits.
+	Separator string
+	// MaxLength limits the length of the slug in runes, if positive.
+	// Slugs are cut after the last word that fits, a single word longer
+	// than MaxLength is cut to it.
+	MaxLength int
+	// StopWords are left out of the slug unless no other words remain.
+	StopWords []string
+	// Existing are the slugs already taken. A slug colliding with one of
+	// them is suffixed with the separator and the first free number from 2,
+	// cut to leave room for the suffix within MaxLength. Slugify fails if
+	// MaxLength leaves no room for a suffix.
+	Existing []string
+}
+
+// ErrEmptySlug is returned by Slugify for texts without letters or digits.
+var ErrEmptySlug error = inputError{errors.New("no letters or digits to make a slug of")}
+
+// foldLetters spells the letters without a decomposition into a base
+// letter and diacritics in the letters of that base and drops apostrophes,
+// so that "don't" makes "dont" rather than "don-t".
+var foldLetters = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l",
+	"đ", "d", "ð", "d", "þ", "th", "ı", "i",
+	"'", "", "’", "",
+)
+
+// slugify returns the slug of s as in opts.
+func slugify(s string, opts SlugOptions) (string, error) {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+	if strings.IndexFunc(sep, isWordRune) >= 0 {
+		return "", invalidInput("slug separator may not hold letters or digits")
+	}
+	words := slugWords(s)
+	if len(words) == 0 {
+		return "", ErrEmptySlug
+	}
+	if len(opts.StopWords) > 0 {
+		stop := make(map[string]bool, len(opts.StopWords))
+		for _, w := range opts.StopWords {
+			for _, sw := range slugWords(w) {
+				stop[sw] = true
+			}
+		}
+		var kept []string
+		for _, w := range words {
+			if !stop[w] {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) > 0 {
+			words = kept
+		}
+	}
+
+	slug := joinSlug(words, sep, opts.MaxLength)
+	if len(opts.Existing) == 0 {
+		return slug, nil
+	}
+	taken := make(map[string]bool, len(opts.Existing))
+	for _, e := range opts.Existing {
+		taken[e] = true
+	}
+	for n := 2; taken[slug]; n++ {
+		suffix := sep + strconv.Itoa(n)
+		max := opts.MaxLength
+		if max > 0 {
+			max -= utf8.RuneCountInString(suffix)
+			if max < 1 {
+				return "", invalidInput("slug %q is taken and a max length of %d leaves no room for the suffix %q", slug, opts.MaxLength, suffix)
+			}
+		}
+		slug = joinSlug(words, sep, max) + suffix
+	}
+	return slug, nil
+}
+
+// slugWords returns the lower cased words of s with their diacritics
+// removed.
+func slugWords(s string) []string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+	folded = foldLetters.Replace(strings.ToLower(folded))
+	return strings.FieldsFunc(folded, func(r rune) bool { return !isWordRune(r) })
+}
+
+// joinSlug joins words with sep, keeping to max runes if positive.
+func joinSlug(words []string, sep string, max int) string {
+	if max <= 0 {
+		return strings.Join(words, sep)
+	}
+	var (
+		b strings.Builder
+		n int
+	)
+	for i, w := range words {
+		add := utf8.RuneCountInString(w)
+		if i > 0 {
+			add += utf8.RuneCountInString(sep)
+		}
+		if n+add > max {
+			if i == 0 {
+				return string([]rune(w)[:max])
+			}
+			break
+		}
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(w)
+		n += add
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package stringsvc
+
+// Text statistics of Stats and the units counted by Count, segmenting
+// text by the rules of Unicode Standard Annex #29 and measuring its
+// display width by Annex #11.
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Units counted by Count.
+const (
+	UnitBytes     = "bytes"     // UTF-8 encoded bytes
+	UnitRunes     = "runes"     // Unicode code points
+	UnitGraphemes = "graphemes" // user-perceived characters
+	UnitWords     = "words"     // words holding a letter or digit
+	UnitLines     = "lines"     // lines, a final line break ends the last line
+	UnitSentences = "sentences" // sentences
+	UnitWidth     = "width"     // terminal columns taken by the widest line
+)
+
+// CountUnits lists the units counted by Count.
+var CountUnits = []string{UnitBytes, UnitRunes, UnitGraphemes, UnitWords, UnitLines, UnitSentences, UnitWidth}
+
+// TextStats are the lengths of a text in all units of Count.
+type TextStats struct {
+	Bytes     int `json:"bytes"`
+	Runes     int `json:"runes"`
+	Graphemes int `json:"graphemes"`
+	Words     int `json:"words"`
+	Lines     int `json:"lines"`
+	Sentences int `json:"sentences"`
+	Width     int `json:"width"`
+}
+
+// textStats returns the TextStats of s.
+func textStats(s string) TextStats {
+	return TextStats{
+		Bytes:     len(s),
+		Runes:     utf8.RuneCountInString(s),
+		Graphemes: uniseg.GraphemeClusterCount(s),
+		Words:     countWords(s),
+		Lines:     countLines(s),
+		Sentences: countSentences(s),
+		Width:     displayWidth(s),
+	}
+}
+
+// countUnit returns the length of s in unit, UnitBytes if empty.
+func countUnit(s, unit string) (int, error) {
+	switch unit {
+	case "", UnitBytes:
+		return len(s), nil
+	case UnitRunes:
+		return utf8.RuneCountInString(s), nil
+	case UnitGraphemes:
+		return uniseg.GraphemeClusterCount(s), nil
+	case UnitWords:
+		return countWords(s), nil
+	case UnitLines:
+		return countLines(s), nil
+	case UnitSentences:
+		return countSentences(s), nil
+	case UnitWidth:
+		return displayWidth(s), nil
+	}
+	return 0, invalidInput("unknown unit %q, want one of %s", unit, strings.Join(CountUnits, ", "))
+}
+
+// countWords counts the word segments of s holding a letter or digit,
+// leaving out those of spaces and punctuation.
+func countWords(s string) int {
+	n, state := 0, -1
+	for s != "" {
+		var word string
+		word, s, state = uniseg.FirstWordInString(s, state)
+		if strings.IndexFunc(word, isWordRune) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// countSentences counts the sentence segments of s other than white space.
+func countSentences(s string) int {
+	n, state := 0, -1
+	for s != "" {
+		var sentence string
+		sentence, s, state = uniseg.FirstSentenceInString(s, state)
+		if strings.TrimSpace(sentence) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// countLines counts the lines of s, ended by the mandatory line breaks of
+// Annex #14 or by the end of s. CR LF is a single line break.
+func countLines(s string) int {
+	n := 0
+	for {
+		i := strings.IndexFunc(s, isLineBreak)
+		if i < 0 {
+			if s != "" {
+				n++
+			}
+			return n
+		}
+		n++
+		if strings.HasPrefix(s[i:], "\r\n") {
+			s = s[i+2:]
+		} else {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			s = s[i+size:]
+		}
+	}
+}
+
+func isLineBreak(r rune) bool {
+	switch r {
+	case '\n', '\v', '\f', '\r', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+// displayWidth returns the number of terminal columns taken by the widest
+// line of s. East Asian wide characters and most emoji take two columns.
+func displayWidth(s string) int {
+	max := 0
+	for _, line := range strings.FieldsFunc(s, isLineBreak) {
+		if w := uniseg.StringWidth(line); w > max {
+			max = w
+		}
+	}
+	return max
+}
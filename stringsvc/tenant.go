@@ -0,0 +1,243 @@
+package stringsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/afrometal/go-kit-svc/feature"
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TenantSettings are the settings of a single tenant.
+type TenantSettings struct {
+	// Callers are the callers acting for the tenant. Their calls belong to
+	// the tenant without naming it.
+	Callers []string `yaml:"callers"`
+	// Limits are the rate limits of the tenant by method, on top of
+	// those of the methods.
+	Limits map[string]Limit `yaml:"limits"`
+	// MaxInputSize is the size in bytes of the largest input the tenant
+	// may send, any size if 0.
+	MaxInputSize int `yaml:"max_input_size"`
+	// Disabled are the methods the tenant may not call.
+	Disabled []string `yaml:"disabled"`
+}
+
+// TenancySettings declare the tenants sharing the service.
+type TenancySettings struct {
+	Strict       bool `yaml:"strict" usage:"reject calls without a tenant or of tenants not configured"`
+	Authenticate bool `yaml:"authenticate" usage:"only trust callers giving their basic auth password"`
+	// Passwords are the SHA-256 digests, hex-encoded, of the basic auth
+	// passwords of callers, checked in authenticate mode.
+	Passwords map[string]string         `yaml:"passwords"`
+	Tenants   map[string]TenantSettings `yaml:"tenants"`
+}
+
+// Validate checks the settings of every tenant, that no caller acts for
+// more than one tenant and, in authenticate mode, that every caller has
+// a password.
+func (s TenancySettings) Validate() error {
+	for c, digest := range s.Passwords {
+		if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("passwords.%s: not a hex-encoded SHA-256 digest", c)
+		}
+	}
+	callers := map[string]string{}
+	for t, ts := range s.Tenants {
+		if err := (PolicySettings{Limits: ts.Limits}).Validate(); err != nil {
+			return fmt.Errorf("tenants.%s.%v", t, err)
+		}
+		if ts.MaxInputSize < 0 {
+			return fmt.Errorf("tenants.%s.max_input_size: must not be negative, got %d", t, ts.MaxInputSize)
+		}
+		for _, m := range ts.Disabled {
+			if !IsMethod(m) {
+				return fmt.Errorf("tenants.%s.disabled: unknown method %q", t, m)
+			}
+		}
+		for _, c := range ts.Callers {
+			if other, ok := callers[c]; ok {
+				return fmt.Errorf("tenants.%s.callers: %q already acts for tenant %q", t, c, other)
+			}
+			if _, ok := s.Passwords[c]; s.Authenticate && !ok {
+				return fmt.Errorf("tenants.%s.callers: %q has no password", t, c)
+			}
+			callers[c] = t
+		}
+	}
+	return nil
+}
+
+// Tenancy resolves the tenant of calls and enforces its settings. Its
+// settings can be replaced while the service runs.
+type Tenancy struct {
+	current atomic.Value // *tenancy
+}
+
+type tenancy struct {
+	strict       bool
+	authenticate bool
+	passwords    map[string][]byte // SHA-256 digests by caller
+	tenants      map[string]*tenant
+	callers      map[string]string
+}
+
+// authentic reports whether the caller of id gave its password.
+func (t *tenancy) authentic(id Identity) bool {
+	want, ok := t.passwords[id.Caller]
+	digest := sha256.Sum256([]byte(id.password))
+	return ok && subtle.ConstantTimeCompare(digest[:], want) == 1
+}
+
+type tenant struct {
+	settings TenantSettings
+	policy   *Policy
+	disabled map[string]bool
+}
+
+// NewTenancy returns a Tenancy with the validated settings.
+func NewTenancy(s TenancySettings) (*Tenancy, error) {
+	t := &Tenancy{}
+	t.current.Store(&tenancy{})
+	if err := t.Update(s); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update validates and applies the settings. Rate limits of tenants are
+// updated as those of a Policy.
+func (t *Tenancy) Update(s TenancySettings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	prev := t.current.Load().(*tenancy)
+	next := &tenancy{
+		strict:       s.Strict,
+		authenticate: s.Authenticate,
+		passwords:    map[string][]byte{},
+		tenants:      map[string]*tenant{},
+		callers:      map[string]string{},
+	}
+	for c, digest := range s.Passwords {
+		next.passwords[c], _ = hex.DecodeString(digest)
+	}
+	for name, ts := range s.Tenants {
+		limits := PolicySettings{Limits: ts.Limits}
+		var policy *Policy
+		if p, ok := prev.tenants[name]; ok {
+			policy = p.policy
+			policy.Update(limits)
+		} else {
+			policy, _ = NewPolicy(limits)
+		}
+		next.tenants[name] = &tenant{settings: ts, policy: policy, disabled: map[string]bool{}}
+		for _, m := range ts.Disabled {
+			next.tenants[name].disabled[m] = true
+		}
+		for _, c := range ts.Callers {
+			next.callers[c] = name
+		}
+	}
+	t.current.Store(next)
+	return nil
+}
+
+// NewTenantMiddleware returns a MethodMiddleware resolving the tenant of
+// every call. Calls without a tenant belong to the tenant their caller
+// acts for, which is stored in the Identity in the context. Calls of
+// configured tenants are held to their rate limits, size limit and
+// disabled methods. In strict mode calls of other tenants are rejected
+// with a TenantError.
+//
+// Without authenticate mode tenants are not isolated from each other: the
+// tenant and caller of the Identity are claimed by the client, which can
+// pass for any tenant. In authenticate mode calls naming a caller without
+// its password are rejected, and so are calls of configured tenants that
+// are not made by one of their callers.
+func NewTenantMiddleware(t *Tenancy) MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				cur := t.current.Load().(*tenancy)
+				id, _ := IdentityFromContext(ctx)
+				if cur.authenticate && id.Caller != "" && !cur.authentic(id) {
+					return nil, TenantError{Tenant: id.Tenant, msg: fmt.Sprintf("caller %q is not authenticated", id.Caller)}
+				}
+				if owner, ok := cur.callers[id.Caller]; ok && id.Caller != "" {
+					if id.Tenant != "" && id.Tenant != owner {
+						return nil, TenantError{Tenant: id.Tenant, msg: fmt.Sprintf("caller %q does not act for tenant %q", id.Caller, id.Tenant)}
+					}
+					if id.Tenant == "" {
+						id.Tenant = owner
+						ctx = WithIdentity(ctx, id)
+					}
+				}
+				ten, ok := cur.tenants[id.Tenant]
+				if ok && cur.authenticate && (id.Caller == "" || cur.callers[id.Caller] != id.Tenant) {
+					return nil, TenantError{Tenant: id.Tenant, msg: fmt.Sprintf("tenant %q requires an authenticated caller of its own", id.Tenant)}
+				}
+				if !ok {
+					switch {
+					case cur.strict && id.Tenant == "":
+						return nil, TenantError{msg: "tenant is required"}
+					case cur.strict:
+						return nil, TenantError{Tenant: id.Tenant, msg: fmt.Sprintf("unknown tenant %q", id.Tenant)}
+					}
+					return next(ctx, request)
+				}
+				ctx = context.WithValue(ctx, tenantLabelKey{}, id.Tenant)
+				if ten.disabled[method] {
+					return nil, DisabledError{Method: method, Tenant: id.Tenant, Reason: feature.ReasonTenant}
+				}
+				if max := ten.settings.MaxInputSize; max > 0 {
+					if r, ok := request.(inputRequest); ok && len(r.input()) > max {
+						return nil, policyError{fmt.Sprintf("input of %d bytes exceeds the limit of %d bytes of the tenant", len(r.input()), max), http.StatusRequestEntityTooLarge, codes.ResourceExhausted}
+					}
+				}
+				if !ten.policy.allow(method) {
+					return nil, ErrRateLimited
+				}
+				return next(ctx, request)
+			}
+		}
+	}
+}
+
+// tenantLabelKey holds the tenant a call is measured for.
+type tenantLabelKey struct{}
+
+// tenantLabel returns the tenant label of metrics of the call. Only
+// configured tenants are told apart, keeping the number of series bounded.
+func tenantLabel(ctx context.Context) string {
+	if t, ok := ctx.Value(tenantLabelKey{}).(string); ok {
+		return t
+	}
+	return "other"
+}
+
+// TenantError is returned for calls of a tenant that is not accepted,
+// unknown in strict mode or not the one of the caller, and of callers
+// that are not authenticated. It is answered with HTTP status 403 and
+// gRPC status PermissionDenied.
+type TenantError struct {
+	Tenant string
+	msg    string
+}
+
+func (e TenantError) Error() string { return e.msg }
+
+// StatusCode implements transport/http.StatusCoder.
+func (e TenantError) StatusCode() int { return http.StatusForbidden }
+
+// GRPCStatus lets the gRPC server answer with the PermissionDenied status.
+func (e TenantError) GRPCStatus() *status.Status {
+	return status.New(codes.PermissionDenied, e.msg)
+}
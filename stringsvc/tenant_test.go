@@ -0,0 +1,78 @@
+package stringsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantAuthenticate(t *testing.T) {
+	digest := sha256.Sum256([]byte("secret"))
+	tenancy, err := NewTenancy(TenancySettings{
+		Strict:       true,
+		Authenticate: true,
+		Passwords:    map[string]string{"alice": hex.EncodeToString(digest[:])},
+		Tenants: map[string]TenantSettings{
+			"acme":   {Callers: []string{"alice"}},
+			"globex": {},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tenant string
+	e := NewTenantMiddleware(tenancy)(MethodTitleCase)(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		id, _ := IdentityFromContext(ctx)
+		tenant = id.Tenant
+		return nil, nil
+	})
+
+	for _, tc := range []struct {
+		name           string
+		user, password string
+		caller, tenant string
+		want           string // tenant of the call, empty if rejected
+	}{
+		{name: "password", user: "alice", password: "secret", want: "acme"},
+		{name: "password and own tenant", user: "alice", password: "secret", tenant: "acme", want: "acme"},
+		{name: "wrong password", user: "alice", password: "guess"},
+		{name: "unknown caller", user: "mallory", password: "secret"},
+		{name: "claimed caller", caller: "alice"},
+		{name: "claimed tenant", tenant: "acme"},
+		{name: "tenant without callers", tenant: "globex"},
+		{name: "password and other tenant", user: "alice", password: "secret", tenant: "globex"},
+	} {
+		r := httptest.NewRequest("POST", "/tc", nil)
+		if tc.user != "" {
+			r.SetBasicAuth(tc.user, tc.password)
+		}
+		if tc.caller != "" {
+			r.Header.Set(callerHeader, tc.caller)
+		}
+		if tc.tenant != "" {
+			r.Header.Set(TenantHeader, tc.tenant)
+		}
+		tenant = ""
+		_, err := e(HTTPToIdentity(context.Background(), r), nil)
+		switch {
+		case tc.want == "" && err == nil:
+			t.Errorf("%s: accepted for tenant %q", tc.name, tenant)
+		case tc.want != "" && err != nil:
+			t.Errorf("%s: %v", tc.name, err)
+		case tenant != tc.want:
+			t.Errorf("%s: got tenant %q, want %q", tc.name, tenant, tc.want)
+		}
+	}
+}
+
+func TestTenancyPasswordRequired(t *testing.T) {
+	_, err := NewTenancy(TenancySettings{
+		Authenticate: true,
+		Tenants:      map[string]TenantSettings{"acme": {Callers: []string{"alice"}}},
+	})
+	if err == nil {
+		t.Error("caller without a password accepted")
+	}
+}
@@ -0,0 +1,109 @@
+package stringsvc
+
+// Truncation of Truncate. Text is only ever cut between grapheme clusters,
+// so that emoji and letters with combining marks are kept whole whatever
+// the unit of the limit.
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// TruncateOptions of Truncate. The zero value cuts s to nothing.
+type TruncateOptions struct {
+	// Limit is the length of the result at most, ellipsis included.
+	Limit int
+	// Unit is UnitBytes, UnitRunes, UnitGraphemes or UnitWidth,
+	// UnitGraphemes if empty.
+	Unit string
+	// WordBoundary cuts after the last word that fits rather than the
+	// last grapheme cluster, unless a single word is longer than Limit.
+	WordBoundary bool
+	// Ellipsis is appended to truncated text, such as "…" or "...".
+	Ellipsis string
+}
+
+// truncate cuts s to opts.Limit as in opts and reports whether it did.
+// Invalid UTF-8 in s is replaced by U+FFFD first.
+func truncate(s string, opts TruncateOptions) (string, bool, error) {
+	var length func(string) int
+	switch opts.Unit {
+	case UnitBytes:
+		length = func(s string) int { return len(s) }
+	case UnitRunes:
+		length = utf8.RuneCountInString
+	case "", UnitGraphemes:
+		length = uniseg.GraphemeClusterCount
+	case UnitWidth:
+		length = uniseg.StringWidth
+	default:
+		return "", false, invalidInput("unknown unit %q, want %s, %s, %s or %s", opts.Unit, UnitBytes, UnitRunes, UnitGraphemes, UnitWidth)
+	}
+	if opts.Limit < 0 {
+		return "", false, invalidInput("limit must not be negative, got %d", opts.Limit)
+	}
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+	if length(s) <= opts.Limit {
+		return s, false, nil
+	}
+	budget := opts.Limit - length(opts.Ellipsis)
+	if budget < 0 {
+		return "", false, invalidInput("ellipsis %q does not fit in the limit of %d %s", opts.Ellipsis, opts.Limit, unitName(opts.Unit))
+	}
+
+	// Find the end of the last grapheme cluster fitting in the budget.
+	var (
+		cut, used int
+		state     = -1
+	)
+	for rest := s; rest != ""; {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		n := length(cluster)
+		if used+n > budget {
+			break
+		}
+		used += n
+		cut += len(cluster)
+	}
+	if opts.WordBoundary {
+		if end := lastBreak(s, cut); end > 0 {
+			cut = end
+		}
+	}
+	return strings.TrimRightFunc(s[:cut], unicode.IsSpace) + opts.Ellipsis, true, nil
+}
+
+// lastBreak returns the offset of the end of the last segment of s ending
+// at or before cut, not counting its trailing white space, 0 if there is
+// none. Segments end at the line break opportunities of Unicode Standard
+// Annex #14, which fall after spaces and hyphens, and between ideographs.
+func lastBreak(s string, cut int) int {
+	var (
+		last, start int
+		state       = -1
+	)
+	for rest := s; rest != ""; {
+		var segment string
+		segment, rest, _, state = uniseg.FirstLineSegmentInString(rest, state)
+		end := start + len(strings.TrimRightFunc(segment, unicode.IsSpace))
+		if end > cut {
+			break
+		}
+		last, start = end, start+len(segment)
+	}
+	return last
+}
+
+// unitName returns unit, or the unit it defaults to if empty.
+func unitName(unit string) string {
+	if unit == "" {
+		return UnitGraphemes
+	}
+	return unit
+}
@@ -0,0 +1,228 @@
+package stringsvc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIVersion is the version of the API implemented by this package and
+// MinAPIVersion the oldest version whose clients are still served. Clients
+// send the version they speak with every call, servers answer with the
+// range of versions they serve and reject calls of clients outside of it.
+//
+// APIVersion is raised whenever a request gains a field or behavior that
+// an older server would silently ignore. MinAPIVersion is raised once the
+// shapes of a version are no longer kept working. stringsvc.proto lists
+// the fields added by each version.
+const (
+	APIVersion    = 4
+	MinAPIVersion = 1
+)
+
+// Headers carrying the API version of the client in requests and the
+// versions served in responses. gRPC calls carry them in lower case
+// metadata. Calls without a version are of version 1, which predates
+// versioning.
+const (
+	APIVersionHeader    = "X-API-Version"
+	MinAPIVersionHeader = "X-API-Min-Version"
+)
+
+// VersionInfo is the range of API versions a server serves.
+type VersionInfo struct {
+	Version    int `json:"version"`
+	MinVersion int `json:"min_version"`
+}
+
+// Served is the VersionInfo of this package.
+var Served = VersionInfo{Version: APIVersion, MinVersion: MinAPIVersion}
+
+// Check returns a VersionError if clients of version v are not served.
+func (i VersionInfo) Check(v int) error {
+	if v < i.MinVersion || v > i.Version {
+		return VersionError{Client: v, Server: i}
+	}
+	return nil
+}
+
+// VersionError is returned for calls of clients speaking an API version
+// the server does not serve. It is answered with HTTP status 400 and gRPC
+// status FailedPrecondition.
+type VersionError struct {
+	Client int
+	Server VersionInfo
+}
+
+func (e VersionError) Error() string {
+	if e.Client < 1 {
+		return fmt.Sprintf("client sent an invalid API version, the server serves versions %d to %d",
+			e.Server.MinVersion, e.Server.Version)
+	}
+	if e.Client > e.Server.Version {
+		return fmt.Sprintf("client speaks API version %d, the server only up to version %d: upgrade the server or use an older client",
+			e.Client, e.Server.Version)
+	}
+	return fmt.Sprintf("client speaks API version %d, the server only from version %d: upgrade the client",
+		e.Client, e.Server.MinVersion)
+}
+
+// StatusCode implements transport/http.StatusCoder.
+func (e VersionError) StatusCode() int { return http.StatusBadRequest }
+
+// GRPCStatus lets the gRPC server answer with the FailedPrecondition status.
+func (e VersionError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type versionKey struct{}
+
+// VersionFromContext returns the API version of the client stored in ctx
+// by the transport.
+func VersionFromContext(ctx context.Context) (int, bool) {
+	v, ok := ctx.Value(versionKey{}).(int)
+	return v, ok
+}
+
+// parseVersion returns the version in a header value, 1 if it is empty
+// and 0, which no server serves, if it is not a version.
+func parseVersion(s string) int {
+	if s == "" {
+		return 1
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || v < 1 {
+		return 0
+	}
+	return v
+}
+
+// HTTPToVersion is a transport/http.RequestFunc that stores the API version
+// of the client in the context.
+func HTTPToVersion(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, versionKey{}, parseVersion(r.Header.Get(APIVersionHeader)))
+}
+
+// GRPCToVersion is a transport/grpc.ServerRequestFunc that stores the API
+// version of the client in the context and sends the versions served in
+// the response header.
+func GRPCToVersion(ctx context.Context, md metadata.MD) context.Context {
+	var v string
+	if vs := md.Get(strings.ToLower(APIVersionHeader)); len(vs) > 0 {
+		v = vs[0]
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(
+		strings.ToLower(APIVersionHeader), strconv.Itoa(APIVersion),
+		strings.ToLower(MinAPIVersionHeader), strconv.Itoa(MinAPIVersion),
+	))
+	return context.WithValue(ctx, versionKey{}, parseVersion(v))
+}
+
+// versionHeaders sets the versions served on every response of next.
+func versionHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(APIVersionHeader, strconv.Itoa(APIVersion))
+		w.Header().Set(MinAPIVersionHeader, strconv.Itoa(MinAPIVersion))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewVersionMiddleware returns a MethodMiddleware rejecting calls of clients
+// speaking an API version that is not served with a VersionError.
+func NewVersionMiddleware() MethodMiddleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				if v, ok := VersionFromContext(ctx); ok {
+					if err := Served.Check(v); err != nil {
+						return nil, err
+					}
+				}
+				return next(ctx, request)
+			}
+		}
+	}
+}
+
+// serverVersion holds the versions served by the server answering a call,
+// filled in by the client transport.
+type serverVersion struct {
+	info VersionInfo
+	seen bool
+}
+
+type serverVersionKey struct{}
+
+// SetHTTPVersion is a transport/http.RequestFunc that sends the API
+// version of the client.
+// Useful in a client.
+func SetHTTPVersion(ctx context.Context, r *http.Request) context.Context {
+	r.Header.Set(APIVersionHeader, strconv.Itoa(APIVersion))
+	return ctx
+}
+
+// HTTPServerVersion is a transport/http.ClientResponseFunc that records the
+// versions served by the server for CheckServerVersion.
+// Useful in a client.
+func HTTPServerVersion(ctx context.Context, r *http.Response) context.Context {
+	if sv, ok := ctx.Value(serverVersionKey{}).(*serverVersion); ok {
+		sv.info = VersionInfo{
+			Version:    parseVersion(r.Header.Get(APIVersionHeader)),
+			MinVersion: parseVersion(r.Header.Get(MinAPIVersionHeader)),
+		}
+		sv.seen = true
+	}
+	return ctx
+}
+
+// SetGRPCVersion is a transport/grpc.ClientRequestFunc that sends the API
+// version of the client.
+// Useful in a client.
+func SetGRPCVersion(ctx context.Context, md *metadata.MD) context.Context {
+	md.Set(strings.ToLower(APIVersionHeader), strconv.Itoa(APIVersion))
+	return ctx
+}
+
+// GRPCServerVersion is a transport/grpc.ClientResponseFunc that records the
+// versions served by the server for CheckServerVersion.
+// Useful in a client.
+func GRPCServerVersion(ctx context.Context, header metadata.MD, _ metadata.MD) context.Context {
+	if sv, ok := ctx.Value(serverVersionKey{}).(*serverVersion); ok {
+		var v, min string
+		if vs := header.Get(strings.ToLower(APIVersionHeader)); len(vs) > 0 {
+			v = vs[0]
+		}
+		if vs := header.Get(strings.ToLower(MinAPIVersionHeader)); len(vs) > 0 {
+			min = vs[0]
+		}
+		sv.info = VersionInfo{Version: parseVersion(v), MinVersion: parseVersion(min)}
+		sv.seen = true
+	}
+	return ctx
+}
+
+// CheckServerVersion is an endpoint.Middleware failing calls answered by
+// a server that does not serve the API version of the client with a
+// VersionError, as recorded by HTTPServerVersion or GRPCServerVersion.
+// Servers predating versioning serve version 1 only.
+// Useful in a client.
+func CheckServerVersion(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		sv := &serverVersion{}
+		response, err := next(context.WithValue(ctx, serverVersionKey{}, sv), request)
+		if sv.seen {
+			if verr := sv.info.Check(APIVersion); verr != nil {
+				return nil, verr
+			}
+		}
+		return response, err
+	}
+}
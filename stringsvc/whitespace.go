@@ -0,0 +1,117 @@
+package stringsvc
+
+// Whitespace modes and character classes of RemoveWhitespace.
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// Modes of RemoveWhitespace.
+const (
+	WhitespaceAll          = "all"           // remove every whitespace character
+	WhitespaceCollapse     = "collapse"      // turn every run into a single space
+	WhitespaceTrim         = "trim"          // remove leading and trailing whitespace
+	WhitespaceKeepNewlines = "keep_newlines" // remove all but line breaks
+	WhitespaceHorizontal   = "horizontal"    // remove spaces and tabs only
+)
+
+// WhitespaceModes lists the modes of RemoveWhitespace.
+var WhitespaceModes = []string{WhitespaceAll, WhitespaceCollapse, WhitespaceTrim, WhitespaceKeepNewlines, WhitespaceHorizontal}
+
+// WhitespaceOptions of RemoveWhitespace. The zero value removes every
+// character for which unicode.IsSpace is true.
+type WhitespaceOptions struct {
+	// Mode is one of WhitespaceModes, WhitespaceAll if empty.
+	Mode string
+	// Class is a character class in regular expression syntax, such as
+	// `[ \t_]` or `\p{Zs}`, of the characters taken for whitespace
+	// instead of those for which unicode.IsSpace is true.
+	Class string
+}
+
+// removeWhitespace removes the whitespace of s as in opts and returns the
+// result along with the positions of the removed characters, counted in
+// runes from the start of s. Runs collapsed to a space keep their first
+// character, replaced by the space.
+func removeWhitespace(s string, opts WhitespaceOptions) (string, []int, error) {
+	isSpace, err := charClass(opts.Class)
+	if err != nil {
+		return "", nil, err
+	}
+	runes := []rune(s)
+	remove := make([]bool, len(runes))
+	switch opts.Mode {
+	case "", WhitespaceAll:
+		for i, r := range runes {
+			remove[i] = isSpace(r)
+		}
+	case WhitespaceKeepNewlines:
+		for i, r := range runes {
+			remove[i] = isSpace(r) && !isLineBreak(r)
+		}
+	case WhitespaceHorizontal:
+		for i, r := range runes {
+			remove[i] = isSpace(r) && (r == '\t' || unicode.Is(unicode.Zs, r))
+		}
+	case WhitespaceTrim:
+		for i := 0; i < len(runes) && isSpace(runes[i]); i++ {
+			remove[i] = true
+		}
+		for i := len(runes) - 1; i >= 0 && isSpace(runes[i]) && !remove[i]; i-- {
+			remove[i] = true
+		}
+	case WhitespaceCollapse:
+		for i, r := range runes {
+			remove[i] = isSpace(r) && i > 0 && isSpace(runes[i-1])
+		}
+	default:
+		return "", nil, invalidInput("unknown whitespace mode %q, want one of %s", opts.Mode, strings.Join(WhitespaceModes, ", "))
+	}
+
+	var (
+		b       strings.Builder
+		removed []int
+	)
+	for i, r := range runes {
+		switch {
+		case remove[i]:
+			removed = append(removed, i)
+		case opts.Mode == WhitespaceCollapse && isSpace(r):
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), removed, nil
+}
+
+// charClass returns a predicate matching the characters of class, given
+// in regular expression syntax, or unicode.IsSpace if class is empty.
+func charClass(class string) (func(rune) bool, error) {
+	if class == "" {
+		return unicode.IsSpace, nil
+	}
+	re, err := syntax.Parse(class, syntax.Perl)
+	if err != nil {
+		return nil, invalidInput("invalid character class %q: %v", class, err)
+	}
+	var ranges []rune
+	switch {
+	case re.Op == syntax.OpCharClass:
+		ranges = re.Rune
+	case re.Op == syntax.OpLiteral && len(re.Rune) == 1 && re.Flags&syntax.FoldCase == 0:
+		ranges = []rune{re.Rune[0], re.Rune[0]}
+	default:
+		return nil, invalidInput("invalid character class %q: not a single character class", class)
+	}
+	return func(r rune) bool {
+		for i := 0; i < len(ranges); i += 2 {
+			if ranges[i] <= r && r <= ranges[i+1] {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,238 @@
+package stringsvc
+
+// Wrapping and alignment of Wrap. Lines are broken at the opportunities
+// of Unicode Standard Annex #14 and measured in terminal columns, East
+// Asian wide characters taking two.
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// Alignments of Wrap.
+const (
+	AlignLeft    = "left"
+	AlignRight   = "right"
+	AlignCenter  = "center"
+	AlignJustify = "justify" // all but the last line of a paragraph filled by widening spaces
+)
+
+// Alignments lists the alignments of Wrap.
+var Alignments = []string{AlignLeft, AlignRight, AlignCenter, AlignJustify}
+
+// Handling of words wider than a line by Wrap.
+const (
+	LongWordsOverflow = "overflow" // put on a line of their own, exceeding the width
+	LongWordsBreak    = "break"    // broken between grapheme clusters
+)
+
+// DefaultWrapWidth is the width of Wrap if none is given.
+const DefaultWrapWidth = 80
+
+// WrapOptions of Wrap. The zero value wraps to DefaultWrapWidth columns,
+// aligned left, letting long words overflow.
+type WrapOptions struct {
+	// Width of lines in columns, DefaultWrapWidth if zero.
+	Width int
+	// LongWords is LongWordsOverflow or LongWordsBreak, LongWordsOverflow
+	// if empty.
+	LongWords string
+	// Align is one of Alignments, AlignLeft if empty.
+	Align string
+	// Indent is the number of spaces before the first line of every
+	// paragraph and Hanging the number before the lines following it.
+	// Both count toward the width.
+	Indent  int
+	Hanging int
+}
+
+// wrapToken is a piece of text between two line break opportunities.
+type wrapToken struct {
+	text  string
+	width int
+	space bool // followed by white space
+	hard  bool // followed by a mandatory break, such as a line separator
+}
+
+// wrapText wraps every line of s, taken for a paragraph, as in opts.
+func wrapText(s string, opts WrapOptions) (string, error) {
+	width := opts.Width
+	if width == 0 {
+		width = DefaultWrapWidth
+	}
+	switch {
+	case width < 0:
+		return "", invalidInput("width must be positive, got %d", width)
+	case opts.Indent < 0 || opts.Hanging < 0:
+		return "", invalidInput("indents must not be negative")
+	case opts.Indent >= width || opts.Hanging >= width:
+		return "", invalidInput("indents must be less than the width of %d columns", width)
+	}
+	switch opts.Align {
+	case "", AlignLeft, AlignRight, AlignCenter, AlignJustify:
+	default:
+		return "", invalidInput("unknown alignment %q, want one of %s", opts.Align, strings.Join(Alignments, ", "))
+	}
+	switch opts.LongWords {
+	case "", LongWordsOverflow, LongWordsBreak:
+	default:
+		return "", invalidInput("unknown long words handling %q, want %s or %s", opts.LongWords, LongWordsOverflow, LongWordsBreak)
+	}
+
+	var out []string
+	for _, para := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		out = append(out, wrapParagraph(para, width, opts)...)
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// wrapParagraph returns the lines of para.
+func wrapParagraph(para string, width int, opts WrapOptions) []string {
+	tokens := wrapTokens(para)
+	if len(tokens) == 0 {
+		return []string{""}
+	}
+	var (
+		lines [][]wrapToken
+		line  []wrapToken
+		used  int
+	)
+	avail := func() int {
+		if len(lines) == 0 {
+			return width - opts.Indent
+		}
+		return width - opts.Hanging
+	}
+	for len(tokens) > 0 {
+		t := tokens[0]
+		add := t.width
+		if len(line) > 0 && line[len(line)-1].space {
+			add++
+		}
+		switch {
+		case used+add <= avail():
+			line = append(line, t)
+			used += add
+			tokens = tokens[1:]
+			if t.hard {
+				lines, line, used = append(lines, line), nil, 0
+			}
+		case len(line) > 0:
+			lines, line, used = append(lines, line), nil, 0
+		case opts.LongWords == LongWordsBreak:
+			head, rest := breakToken(t, avail())
+			lines = append(lines, []wrapToken{head})
+			tokens[0] = rest
+		default:
+			// An overflowing word still ends its line, whatever follows.
+			lines = append(lines, []wrapToken{t})
+			tokens = tokens[1:]
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		indent := opts.Hanging
+		if i == 0 {
+			indent = opts.Indent
+		}
+		// Lines ending at a mandatory break, such as U+2028, end the
+		// paragraph for the alignment too.
+		last := i == len(lines)-1 || l[len(l)-1].hard
+		out[i] = strings.Repeat(" ", indent) + alignLine(l, width-indent, opts.Align, last)
+	}
+	return out
+}
+
+// wrapTokens splits para at its line break opportunities.
+func wrapTokens(para string) []wrapToken {
+	var tokens []wrapToken
+	state := -1
+	for para != "" {
+		var (
+			segment string
+			hard    bool
+		)
+		segment, para, hard, state = uniseg.FirstLineSegmentInString(para, state)
+		hard = hard && para != ""
+		text := strings.TrimRightFunc(segment, unicode.IsSpace)
+		if text == "" {
+			if len(tokens) > 0 {
+				tokens[len(tokens)-1].space = true
+				tokens[len(tokens)-1].hard = tokens[len(tokens)-1].hard || hard
+			}
+			continue
+		}
+		tokens = append(tokens, wrapToken{text, uniseg.StringWidth(text), len(text) < len(segment), hard})
+	}
+	return tokens
+}
+
+// breakToken splits t after the grapheme clusters fitting in width, at
+// least one.
+func breakToken(t wrapToken, width int) (head, rest wrapToken) {
+	var (
+		n, w  int
+		state = -1
+	)
+	for s := t.text; s != ""; {
+		var cluster string
+		var cw int
+		cluster, s, cw, state = uniseg.FirstGraphemeClusterInString(s, state)
+		if w+cw > width && n > 0 {
+			break
+		}
+		n += len(cluster)
+		w += cw
+	}
+	return wrapToken{t.text[:n], w, false, false}, wrapToken{t.text[n:], t.width - w, t.space, t.hard}
+}
+
+// alignLine joins the tokens of a line and aligns it in width columns.
+func alignLine(line []wrapToken, width int, align string, last bool) string {
+	var (
+		b    strings.Builder
+		used int
+		gaps int
+	)
+	for i, t := range line {
+		if i > 0 && line[i-1].space {
+			gaps++
+			used++
+		}
+		used += t.width
+	}
+	pad := width - used
+	if pad < 0 {
+		pad = 0
+	}
+	switch align {
+	case AlignRight:
+		b.WriteString(strings.Repeat(" ", pad))
+	case AlignCenter:
+		b.WriteString(strings.Repeat(" ", pad/2))
+	}
+	extra := 0
+	if align == AlignJustify && !last && gaps > 0 {
+		extra = pad
+	}
+	gap := 0
+	for i, t := range line {
+		if i > 0 && line[i-1].space {
+			// Spread the extra spaces, the first gaps taking the remainder.
+			n := 1 + extra/gaps
+			if gap < extra%gaps {
+				n++
+			}
+			b.WriteString(strings.Repeat(" ", n))
+			gap++
+		}
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
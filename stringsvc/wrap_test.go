@@ -0,0 +1,27 @@
+package stringsvc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapJustify(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want []string
+	}{
+		{"aa bb cc dd ee", []string{"aa  bb  cc", "dd ee"}},
+		// A mandatory break ends the line like the end of the paragraph.
+		{"aa bb\u2028cc dd ee ff", []string{"aa bb", "cc  dd  ee", "ff"}},
+		{"aa bb\u2029cc", []string{"aa bb", "cc"}},
+	} {
+		got, err := wrapText(tc.s, WrapOptions{Width: 10, Align: AlignJustify})
+		if err != nil {
+			t.Errorf("%q: %v", tc.s, err)
+			continue
+		}
+		if want := strings.Join(tc.want, "\n"); got != want {
+			t.Errorf("%q: got %q, want %q", tc.s, got, want)
+		}
+	}
+}